@@ -1,5 +1,7 @@
 package asa
 
+import "context"
+
 // AccessControlListService handles communication with build-related methods of the Apple Search Ads API
 //
 // https://developer.apple.com/documentation/apple_search_ads/calling_the_apple_search_ads_api
@@ -114,8 +116,13 @@ type BaseResponse struct {
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_user_acl
 func (s *AccessControlListService) GetUserACL() (*UserACLListResponse, error) {
+	return s.GetUserACLWithContext(context.Background())
+}
+
+// GetUserACLWithContext is like GetUserACL but uses ctx to cancel the request or bound its deadline.
+func (s *AccessControlListService) GetUserACLWithContext(ctx context.Context) (*UserACLListResponse, error) {
 	url := "acls"
 	resp := new(UserACLListResponse)
-	err := s.client.get(url, resp)
+	err := s.client.get(ctx, url, resp)
 	return resp, err
 }
@@ -0,0 +1,300 @@
+package asa
+
+import (
+	"context"
+	"fmt"
+)
+
+// AdService handles communication with ad-related methods of the Apple Search Ads API
+//
+// https://developer.apple.com/documentation/apple_search_ads/ads
+type AdService service
+
+// AdStatus is the user-controlled status to enable or pause the ad.
+type AdStatus string
+
+const (
+	// AdStatusEnabled is for an ad status on Enabled.
+	AdStatusEnabled AdStatus = "ENABLED"
+	// AdStatusPaused is for an ad status on Paused.
+	AdStatusPaused AdStatus = "PAUSED"
+)
+
+// AdServingStatus is the status of whether the ad is serving.
+type AdServingStatus string
+
+const (
+	// AdServingStatusRunning is for an ad serving status on Running.
+	AdServingStatusRunning AdServingStatus = "RUNNING"
+	// AdServingStatusNotRunning is for an ad serving status on Not Running.
+	AdServingStatusNotRunning AdServingStatus = "NOT_RUNNING"
+)
+
+// AdCreativeType is the type of creative an ad references.
+type AdCreativeType string
+
+const (
+	// AdCreativeTypeCustomProductPage is for an ad that uses a custom product page.
+	AdCreativeTypeCustomProductPage AdCreativeType = "CUSTOM_PRODUCT_PAGE"
+)
+
+// AdServingStateReason is a reason that displays when an ad isn’t running.
+type AdServingStateReason string
+
+const (
+	// AdServingStateReasonAdApprovalPending is for an ad serving state reason Ad Approval Pending.
+	AdServingStateReasonAdApprovalPending AdServingStateReason = "AD_APPROVAL_PENDING"
+	// AdServingStateReasonAdApprovalRejected is for an ad serving state reason Ad Approval Rejected.
+	AdServingStateReasonAdApprovalRejected AdServingStateReason = "AD_APPROVAL_REJECTED"
+	// AdServingStateReasonAdProcessingInProgress is for an ad serving state reason Ad Processing In Progress.
+	AdServingStateReasonAdProcessingInProgress AdServingStateReason = "AD_PROCESSING_IN_PROGRESS"
+	// AdServingStateReasonCreativeSetInvalid is for an ad serving state reason Creative Set Invalid.
+	AdServingStateReasonCreativeSetInvalid AdServingStateReason = "CREATIVE_SET_INVALID"
+	// AdServingStateReasonCreativeSetUnsupported is for an ad serving state reason Creative Set Unsupported.
+	AdServingStateReasonCreativeSetUnsupported AdServingStateReason = "CREATIVE_SET_UNSUPPORTED"
+	// AdServingStateReasonDeletedByUser is for an ad serving state reason Deleted By User.
+	AdServingStateReasonDeletedByUser AdServingStateReason = "DELETED_BY_USER"
+	// AdServingStateReasonPausedByUser is for an ad serving state reason Paused By User.
+	AdServingStateReasonPausedByUser AdServingStateReason = "PAUSED_BY_USER"
+	// AdServingStateReasonProductPageDeleted is for an ad serving state reason Product Page Deleted.
+	AdServingStateReasonProductPageDeleted AdServingStateReason = "PRODUCT_PAGE_DELETED"
+	// AdServingStateReasonProductPageHidden is for an ad serving state reason Product Page Hidden.
+	AdServingStateReasonProductPageHidden AdServingStateReason = "PRODUCT_PAGE_HIDDEN"
+	// AdServingStateReasonProductPageIncompatible is for an ad serving state reason Product Page Incompatible.
+	AdServingStateReasonProductPageIncompatible AdServingStateReason = "PRODUCT_PAGE_INCOMPATIBLE"
+	// AdServingStateReasonProductPageInsufficientAssets is for an ad serving state reason Product Page Insufficient Assets.
+	AdServingStateReasonProductPageInsufficientAssets AdServingStateReason = "PRODUCT_PAGE_INSUFFICIENT_ASSETS"
+)
+
+// Ad is the object to use to assign a creative to an ad group
+//
+// https://developer.apple.com/documentation/apple_search_ads/ad
+type Ad struct {
+	AdGroupID           int64                  `json:"adGroupId,omitempty"`
+	CampaignID          int64                  `json:"campaignId,omitempty"`
+	CreationTime        DateTime               `json:"creationTime,omitempty"`
+	CreativeID          int64                  `json:"creativeId,omitempty"`
+	CreativeType        AdCreativeType         `json:"creativeType,omitempty"`
+	Deleted             bool                   `json:"deleted,omitempty"`
+	ID                  int64                  `json:"id,omitempty"`
+	ModificationTime    DateTime               `json:"modificationTime,omitempty"`
+	Name                string                 `json:"name,omitempty"`
+	OrgID               int64                  `json:"orgId,omitempty"`
+	ServingStateReasons []AdServingStateReason `json:"servingStateReasons,omitempty"`
+	ServingStatus       AdServingStatus        `json:"servingStatus,omitempty"`
+	Status              AdStatus               `json:"status,omitempty"`
+}
+
+// AdCreate is the request body to create an ad
+//
+// https://developer.apple.com/documentation/apple_search_ads/adcreate
+type AdCreate struct {
+	CreativeID int64    `json:"creativeId"`
+	Name       string   `json:"name"`
+	Status     AdStatus `json:"status,omitempty"`
+}
+
+// AdUpdate is the request body to update an ad
+//
+// https://developer.apple.com/documentation/apple_search_ads/adupdate
+type AdUpdate struct {
+	Name   string   `json:"name,omitempty"`
+	Status AdStatus `json:"status,omitempty"`
+}
+
+// GetAllAdsQuery defines query parameter for GetAllAds endpoint.
+type GetAllAdsQuery struct {
+	Limit  int32 `form:"limit,omitempty"`
+	Offset int32 `form:"offset,omitempty"`
+}
+
+// AdResponse is a container for the ad response body
+//
+// https://developer.apple.com/documentation/apple_search_ads/adresponse
+type AdResponse struct {
+	Ad         *Ad                `json:"data,omitempty"`
+	Error      *ErrorResponseBody `json:"error,omitempty"`
+	Pagination *PageDetail        `json:"pagination,omitempty"`
+}
+
+// AdListResponse is the response details of ad requests
+//
+// https://developer.apple.com/documentation/apple_search_ads/adlistresponse
+type AdListResponse struct {
+	Ads        []*Ad              `json:"data,omitempty"`
+	Error      *ErrorResponseBody `json:"error,omitempty"`
+	Pagination *PageDetail        `json:"pagination,omitempty"`
+}
+
+// ProductPageReason is a reason an ad creative was rejected during review
+//
+// https://developer.apple.com/documentation/apple_search_ads/productpagereason
+type ProductPageReason struct {
+	AdamID          int64  `json:"adamId,omitempty"`
+	Comment         string `json:"comment,omitempty"`
+	CountryOrRegion string `json:"countryOrRegion,omitempty"`
+	ID              int64  `json:"id,omitempty"`
+	LanguageCode    string `json:"languageCode,omitempty"`
+	ProductPageID   string `json:"productPageId,omitempty"`
+	ReasonCode      string `json:"reasonCode,omitempty"`
+	ReasonLevel     string `json:"reasonLevel,omitempty"`
+	ReasonType      string `json:"reasonType,omitempty"`
+	SupplySource    string `json:"supplySource,omitempty"`
+}
+
+// ProductPageReasonListResponse is the response details of ad creative rejection reason requests
+//
+// https://developer.apple.com/documentation/apple_search_ads/productpagereasonresponse
+type ProductPageReasonListResponse struct {
+	Reasons    []*ProductPageReason `json:"data,omitempty"`
+	Error      *ErrorResponseBody   `json:"error,omitempty"`
+	Pagination *PageDetail          `json:"pagination,omitempty"`
+}
+
+// CreateAd creates an ad in an ad group with a creative
+//
+// https://developer.apple.com/documentation/apple_search_ads/create_an_ad
+func (s *AdService) CreateAd(campaignID int64, adGroupID int64, ad *AdCreate) (*AdResponse, error) {
+	return s.CreateAdWithContext(context.Background(), campaignID, adGroupID, ad)
+}
+
+// CreateAdWithContext is like CreateAd but uses ctx to cancel the request or bound its deadline.
+func (s *AdService) CreateAdWithContext(ctx context.Context, campaignID int64, adGroupID int64, ad *AdCreate) (*AdResponse, error) {
+	url := fmt.Sprintf("campaigns/%d/adgroups/%d/ads", campaignID, adGroupID)
+	res := new(AdResponse)
+	err := s.client.post(ctx, url, res, ad)
+
+	return res, err
+}
+
+// GetAd fetches an ad assigned to an ad group
+//
+// https://developer.apple.com/documentation/apple_search_ads/get_an_ad
+func (s *AdService) GetAd(campaignID int64, adGroupID int64, adID int64) (*AdResponse, error) {
+	return s.GetAdWithContext(context.Background(), campaignID, adGroupID, adID)
+}
+
+// GetAdWithContext is like GetAd but uses ctx to cancel the request or bound its deadline.
+func (s *AdService) GetAdWithContext(ctx context.Context, campaignID int64, adGroupID int64, adID int64) (*AdResponse, error) {
+	url := fmt.Sprintf("campaigns/%d/adgroups/%d/ads/%d", campaignID, adGroupID, adID)
+	res := new(AdResponse)
+	err := s.client.get(ctx, url, res)
+
+	return res, err
+}
+
+// GetAllAds fetches all ads assigned to an ad group
+//
+// https://developer.apple.com/documentation/apple_search_ads/get_all_ads
+func (s *AdService) GetAllAds(campaignID int64, adGroupID int64, params *GetAllAdsQuery) (*AdListResponse, error) {
+	return s.GetAllAdsWithContext(context.Background(), campaignID, adGroupID, params)
+}
+
+// GetAllAdsWithContext is like GetAllAds but uses ctx to cancel the request or bound its deadline.
+func (s *AdService) GetAllAdsWithContext(ctx context.Context, campaignID int64, adGroupID int64, params *GetAllAdsQuery) (*AdListResponse, error) {
+	url := fmt.Sprintf("campaigns/%d/adgroups/%d/ads", campaignID, adGroupID)
+	res := new(AdListResponse)
+	err := s.client.get(ctx, url, res, params)
+
+	return res, err
+}
+
+// GetAllAdsPager returns a Pager that walks all ads of an ad group page by page via GetAllAds.
+func (s *AdService) GetAllAdsPager(campaignID int64, adGroupID int64) *Pager[*Ad] {
+	return NewPager(func(ctx context.Context, offset, limit int) ([]*Ad, *PageDetail, error) {
+		res, err := s.GetAllAdsWithContext(ctx, campaignID, adGroupID, &GetAllAdsQuery{Limit: int32(limit), Offset: int32(offset)})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Ads, res.Pagination, nil
+	})
+}
+
+// FindAds fetches ads within a campaign with selector operators
+//
+// https://developer.apple.com/documentation/apple_search_ads/find_ads
+func (s *AdService) FindAds(campaignID int64, selector *Selector) (*AdListResponse, error) {
+	return s.FindAdsWithContext(context.Background(), campaignID, selector)
+}
+
+// FindAdsWithContext is like FindAds but uses ctx to cancel the request or bound its deadline.
+func (s *AdService) FindAdsWithContext(ctx context.Context, campaignID int64, selector *Selector) (*AdListResponse, error) {
+	url := fmt.Sprintf("campaigns/%d/ads/find", campaignID)
+	res := new(AdListResponse)
+	err := s.client.find(ctx, url, res, selector)
+
+	return res, err
+}
+
+// FindAdsPager returns a Pager that walks all ads matching selector page by page via FindAds.
+func (s *AdService) FindAdsPager(campaignID int64, selector *Selector) *Pager[*Ad] {
+	return newSelectorPager(selector, func(ctx context.Context, offset, limit int) ([]*Ad, *PageDetail, error) {
+		res, err := s.FindAdsWithContext(ctx, campaignID, pageSelector(selector, offset, limit))
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Ads, res.Pagination, nil
+	})
+}
+
+// FindAdsProjected is like FindAds but reports which fields each record contains.
+func (s *AdService) FindAdsProjected(campaignID int64, selector *Selector) (*ProjectedListResponse[Ad], error) {
+	return s.FindAdsProjectedWithContext(context.Background(), campaignID, selector)
+}
+
+// FindAdsProjectedWithContext is like FindAdsProjected but uses ctx to cancel the request or bound its deadline.
+func (s *AdService) FindAdsProjectedWithContext(ctx context.Context, campaignID int64, selector *Selector) (*ProjectedListResponse[Ad], error) {
+	url := fmt.Sprintf("campaigns/%d/ads/find", campaignID)
+	res := new(ProjectedListResponse[Ad])
+	err := s.client.find(ctx, url, res, selector)
+
+	return res, err
+}
+
+// UpdateAd updates the name or status of an ad
+//
+// https://developer.apple.com/documentation/apple_search_ads/update_an_ad
+func (s *AdService) UpdateAd(campaignID int64, adGroupID int64, adID int64, req *AdUpdate) (*AdResponse, error) {
+	return s.UpdateAdWithContext(context.Background(), campaignID, adGroupID, adID, req)
+}
+
+// UpdateAdWithContext is like UpdateAd but uses ctx to cancel the request or bound its deadline.
+func (s *AdService) UpdateAdWithContext(ctx context.Context, campaignID int64, adGroupID int64, adID int64, req *AdUpdate) (*AdResponse, error) {
+	url := fmt.Sprintf("campaigns/%d/adgroups/%d/ads/%d", campaignID, adGroupID, adID)
+	res := new(AdResponse)
+	err := s.client.put(ctx, url, res, req)
+
+	return res, err
+}
+
+// DeleteAd deletes an ad from an ad group
+//
+// https://developer.apple.com/documentation/apple_search_ads/delete_an_ad
+func (s *AdService) DeleteAd(campaignID int64, adGroupID int64, adID int64) (*BaseResponse, error) {
+	return s.DeleteAdWithContext(context.Background(), campaignID, adGroupID, adID)
+}
+
+// DeleteAdWithContext is like DeleteAd but uses ctx to cancel the request or bound its deadline.
+func (s *AdService) DeleteAdWithContext(ctx context.Context, campaignID int64, adGroupID int64, adID int64) (*BaseResponse, error) {
+	url := fmt.Sprintf("campaigns/%d/adgroups/%d/ads/%d", campaignID, adGroupID, adID)
+	res := new(BaseResponse)
+	err := s.client.delete(ctx, url, res)
+
+	return res, err
+}
+
+// FindAdRejectionReasons fetches the reasons ad creatives were rejected during review
+//
+// https://developer.apple.com/documentation/apple_search_ads/find_ad_creative_rejection_reasons
+func (s *AdService) FindAdRejectionReasons(selector *Selector) (*ProductPageReasonListResponse, error) {
+	return s.FindAdRejectionReasonsWithContext(context.Background(), selector)
+}
+
+// FindAdRejectionReasonsWithContext is like FindAdRejectionReasons but uses ctx to cancel the request or bound its deadline.
+func (s *AdService) FindAdRejectionReasonsWithContext(ctx context.Context, selector *Selector) (*ProductPageReasonListResponse, error) {
+	url := "product-page-reasons/find"
+	res := new(ProductPageReasonListResponse)
+	err := s.client.find(ctx, url, res, selector)
+
+	return res, err
+}
@@ -0,0 +1,72 @@
+package asa
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -run TestAdService
+func TestAdService(t *testing.T) {
+	t.Parallel()
+
+	const ad = `{"id":3,"campaignId":1,"adGroupId":2,"creativeId":9,"creativeType":"CUSTOM_PRODUCT_PAGE",` +
+		`"name":"variant","status":"PAUSED","servingStatus":"NOT_RUNNING","servingStateReasons":["PAUSED_BY_USER"]}`
+	var requests []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+		switch {
+		case r.URL.Path == "/product-page-reasons/find":
+			_, _ = w.Write([]byte(`{"data":[{"id":7,"adamId":123,"productPageId":"abc","reasonCode":"TEXT","reasonType":"REJECTION"}]}`))
+		case r.Method == http.MethodDelete:
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/campaigns/1/adgroups/2/ads/3" || r.Method == http.MethodPost && r.URL.Path == "/campaigns/1/adgroups/2/ads":
+			_, _ = w.Write([]byte(`{"data":` + ad + `}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":[` + ad + `],"pagination":{"totalResults":1,"startIndex":0,"itemsPerPage":1}}`))
+		}
+	})
+
+	created, err := c.Ads.CreateAd(1, 2, &AdCreate{CreativeID: 9, Name: "variant", Status: AdStatusPaused})
+	assert.NoError(t, err)
+	assert.Equal(t, &Ad{
+		ID: 3, CampaignID: 1, AdGroupID: 2, CreativeID: 9, CreativeType: AdCreativeTypeCustomProductPage,
+		Name: "variant", Status: AdStatusPaused, ServingStatus: AdServingStatusNotRunning,
+		ServingStateReasons: []AdServingStateReason{AdServingStateReasonPausedByUser},
+	}, created.Ad)
+
+	_, err = c.Ads.GetAd(1, 2, 3)
+	assert.NoError(t, err)
+	all, err := c.Ads.GetAllAds(1, 2, &GetAllAdsQuery{Limit: 5})
+	assert.NoError(t, err)
+	assert.Len(t, all.Ads, 1)
+	found, err := c.Ads.FindAdsPager(1, NewAdSelector().Where("status").Equals(AdStatusPaused).MustBuild()).All(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, found, 1)
+	_, err = c.Ads.UpdateAd(1, 2, 3, &AdUpdate{Status: AdStatusEnabled})
+	assert.NoError(t, err)
+	_, err = c.Ads.DeleteAd(1, 2, 3)
+	assert.NoError(t, err)
+	reasons, err := c.Ads.FindAdRejectionReasons(NewSelector().Where("adamId").Equals(123).MustBuild())
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", reasons.Reasons[0].ProductPageID)
+
+	find, _ := json.Marshal(&Selector{
+		Conditions: []*Condition{{Field: "status", Operator: ConditionOperatorEquals, Values: []string{"PAUSED"}}},
+		Pagination: &Pagination{Limit: 1000},
+	})
+	assert.Equal(t, []string{
+		`POST /campaigns/1/adgroups/2/ads {"creativeId":9,"name":"variant","status":"PAUSED"}`,
+		"GET /campaigns/1/adgroups/2/ads/3 ",
+		"GET /campaigns/1/adgroups/2/ads?limit=5 ",
+		"POST /campaigns/1/ads/find " + string(find),
+		`PUT /campaigns/1/adgroups/2/ads/3 {"status":"ENABLED"}`,
+		"DELETE /campaigns/1/adgroups/2/ads/3 ",
+		`POST /product-page-reasons/find {"conditions":[{"field":"adamId","operator":"EQUALS","values":["123"]}]}`,
+	}, requests)
+}
@@ -1,6 +1,7 @@
 package asa
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -356,9 +357,39 @@ type AdGroupListResponse struct {
 //
 // https://developer.apple.com/documentation/apple_search_ads/find_ad_groups
 func (s *AdGroupService) FindAdGroups(campaignID int64, selector *Selector) (*AdGroupListResponse, error) {
+	return s.FindAdGroupsWithContext(context.Background(), campaignID, selector)
+}
+
+// FindAdGroupsWithContext is like FindAdGroups but uses ctx to cancel the request or bound its deadline.
+func (s *AdGroupService) FindAdGroupsWithContext(ctx context.Context, campaignID int64, selector *Selector) (*AdGroupListResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups/find", campaignID)
 	res := new(AdGroupListResponse)
-	err := s.client.post(url, res, selector)
+	err := s.client.find(ctx, url, res, selector)
+	return res, err
+}
+
+// FindAdGroupsPager returns a Pager that walks all ad groups matching selector page by page via FindAdGroups.
+func (s *AdGroupService) FindAdGroupsPager(campaignID int64, selector *Selector) *Pager[*AdGroup] {
+	return newSelectorPager(selector, func(ctx context.Context, offset, limit int) ([]*AdGroup, *PageDetail, error) {
+		res, err := s.FindAdGroupsWithContext(ctx, campaignID, pageSelector(selector, offset, limit))
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.AdGroups, res.Pagination, nil
+	})
+}
+
+// FindAdGroupsProjected is like FindAdGroups but reports which fields each record contains.
+func (s *AdGroupService) FindAdGroupsProjected(campaignID int64, selector *Selector) (*ProjectedListResponse[AdGroup], error) {
+	return s.FindAdGroupsProjectedWithContext(context.Background(), campaignID, selector)
+}
+
+// FindAdGroupsProjectedWithContext is like FindAdGroupsProjected but uses ctx to cancel the request or bound its deadline.
+func (s *AdGroupService) FindAdGroupsProjectedWithContext(ctx context.Context, campaignID int64, selector *Selector) (*ProjectedListResponse[AdGroup], error) {
+	url := fmt.Sprintf("campaigns/%d/adgroups/find", campaignID)
+	res := new(ProjectedListResponse[AdGroup])
+	err := s.client.find(ctx, url, res, selector)
+
 	return res, err
 }
 
@@ -366,9 +397,14 @@ func (s *AdGroupService) FindAdGroups(campaignID int64, selector *Selector) (*Ad
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_an_ad_group
 func (s *AdGroupService) GetAdGroup(campaignID int64, adGroupID int64) (*AdGroupResponse, error) {
+	return s.GetAdGroupWithContext(context.Background(), campaignID, adGroupID)
+}
+
+// GetAdGroupWithContext is like GetAdGroup but uses ctx to cancel the request or bound its deadline.
+func (s *AdGroupService) GetAdGroupWithContext(ctx context.Context, campaignID int64, adGroupID int64) (*AdGroupResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups/%d", campaignID, adGroupID)
 	res := new(AdGroupResponse)
-	err := s.client.get(url, res)
+	err := s.client.get(ctx, url, res)
 	return res, err
 }
 
@@ -376,20 +412,41 @@ func (s *AdGroupService) GetAdGroup(campaignID int64, adGroupID int64) (*AdGroup
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_all_ad_groups
 func (s *AdGroupService) GetAllAdGroups(campaignID int64, params *GetAllAdGroupsQuery) (*AdGroupListResponse, error) {
+	return s.GetAllAdGroupsWithContext(context.Background(), campaignID, params)
+}
+
+// GetAllAdGroupsWithContext is like GetAllAdGroups but uses ctx to cancel the request or bound its deadline.
+func (s *AdGroupService) GetAllAdGroupsWithContext(ctx context.Context, campaignID int64, params *GetAllAdGroupsQuery) (*AdGroupListResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups", campaignID)
 	res := new(AdGroupListResponse)
-	err := s.client.get(url, res, params)
+	err := s.client.get(ctx, url, res, params)
 
 	return res, err
 }
 
+// GetAllAdGroupsPager returns a Pager that walks all ad groups of a campaign page by page via GetAllAdGroups.
+func (s *AdGroupService) GetAllAdGroupsPager(campaignID int64) *Pager[*AdGroup] {
+	return NewPager(func(ctx context.Context, offset, limit int) ([]*AdGroup, *PageDetail, error) {
+		res, err := s.GetAllAdGroupsWithContext(ctx, campaignID, &GetAllAdGroupsQuery{Limit: int32(limit), Offset: int32(offset)})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.AdGroups, res.Pagination, nil
+	})
+}
+
 // CreateAdGroup creates an ad group as part of a campaign
 //
 // https://developer.apple.com/documentation/apple_search_ads/create_an_ad_group
 func (s *AdGroupService) CreateAdGroup(campaignID int64, adGroup *AdGroup) (*AdGroupResponse, error) {
+	return s.CreateAdGroupWithContext(context.Background(), campaignID, adGroup)
+}
+
+// CreateAdGroupWithContext is like CreateAdGroup but uses ctx to cancel the request or bound its deadline.
+func (s *AdGroupService) CreateAdGroupWithContext(ctx context.Context, campaignID int64, adGroup *AdGroup) (*AdGroupResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups", campaignID)
 	res := new(AdGroupResponse)
-	err := s.client.post(url, res, adGroup)
+	err := s.client.post(ctx, url, res, adGroup)
 
 	return res, err
 }
@@ -398,9 +455,14 @@ func (s *AdGroupService) CreateAdGroup(campaignID int64, adGroup *AdGroup) (*AdG
 //
 // https://developer.apple.com/documentation/apple_search_ads/delete_an_adgroup
 func (s *AdGroupService) DeleteAdGroup(campaignID int64, adGroupID int64) (*BaseResponse, error) {
+	return s.DeleteAdGroupWithContext(context.Background(), campaignID, adGroupID)
+}
+
+// DeleteAdGroupWithContext is like DeleteAdGroup but uses ctx to cancel the request or bound its deadline.
+func (s *AdGroupService) DeleteAdGroupWithContext(ctx context.Context, campaignID int64, adGroupID int64) (*BaseResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups/%d", campaignID, adGroupID)
 	res := new(BaseResponse)
-	err := s.client.delete(url, res)
+	err := s.client.delete(ctx, url, res)
 
 	return res, err
 }
@@ -409,9 +471,14 @@ func (s *AdGroupService) DeleteAdGroup(campaignID int64, adGroupID int64) (*Base
 //
 // https://developer.apple.com/documentation/apple_search_ads/update_an_ad_group
 func (s *AdGroupService) UpdateAdGroup(campaignID int64, adGroupID int64, req *AdGroupUpdateRequest) (*AdGroupResponse, error) {
+	return s.UpdateAdGroupWithContext(context.Background(), campaignID, adGroupID, req)
+}
+
+// UpdateAdGroupWithContext is like UpdateAdGroup but uses ctx to cancel the request or bound its deadline.
+func (s *AdGroupService) UpdateAdGroupWithContext(ctx context.Context, campaignID int64, adGroupID int64, req *AdGroupUpdateRequest) (*AdGroupResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups/%d", campaignID, adGroupID)
 	res := new(AdGroupResponse)
-	err := s.client.put(url, res, req)
+	err := s.client.put(ctx, url, res, req)
 
 	return res, err
 }
@@ -1,6 +1,9 @@
 package asa
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // AppService handles communication with build-related methods of the Apple Search Ads API
 //
@@ -54,24 +57,259 @@ type EligibilityRecordListResponse struct {
 	Pagination         *PageDetail          `json:"pagination,omitempty"`
 }
 
+// ProductPageState is the state of a custom product page.
+type ProductPageState string
+
+const (
+	// ProductPageStateVisible is for a product page state on Visible.
+	ProductPageStateVisible ProductPageState = "VISIBLE"
+	// ProductPageStateHidden is for a product page state on Hidden.
+	ProductPageStateHidden ProductPageState = "HIDDEN"
+)
+
+// ProductPageDetail is the custom product page metadata of an app
+//
+// https://developer.apple.com/documentation/apple_search_ads/productpagedetail
+type ProductPageDetail struct {
+	AdamID           int64            `json:"adamId,omitempty"`
+	CreationTime     DateTime         `json:"creationTime,omitempty"`
+	DeepLink         string           `json:"deepLink,omitempty"`
+	ID               string           `json:"id,omitempty"`
+	ModificationTime DateTime         `json:"modificationTime,omitempty"`
+	Name             string           `json:"name,omitempty"`
+	State            ProductPageState `json:"state,omitempty"`
+}
+
+// GetProductPagesQuery defines query parameter for GetProductPages endpoint.
+type GetProductPagesQuery struct {
+	// Name filters product pages by name.
+	Name string `form:"name,omitempty"`
+	// States is a comma-separated list of ProductPageState values, such as "VISIBLE,HIDDEN".
+	States string `form:"states,omitempty"`
+}
+
+// ProductPageDetailResponse is a container for the product page response body
+//
+// https://developer.apple.com/documentation/apple_search_ads/productpagedetailresponse
+type ProductPageDetailResponse struct {
+	ProductPage *ProductPageDetail `json:"data,omitempty"`
+	Error       *ErrorResponseBody `json:"error,omitempty"`
+}
+
+// ProductPageDetailListResponse is the response details of product page requests
+//
+// https://developer.apple.com/documentation/apple_search_ads/productpagedetaillistresponse
+type ProductPageDetailListResponse struct {
+	ProductPages []*ProductPageDetail `json:"data,omitempty"`
+	Error        *ErrorResponseBody   `json:"error,omitempty"`
+	Pagination   *PageDetail          `json:"pagination,omitempty"`
+}
+
+// MediaAppPreviewOrScreenshotsDetail is an app preview or screenshot asset of a product page locale
+//
+// https://developer.apple.com/documentation/apple_search_ads/mediaapppreviewoscreenshotsdetail
+type MediaAppPreviewOrScreenshotsDetail struct {
+	AssetGenID   string `json:"assetGenId,omitempty"`
+	AssetType    string `json:"assetType,omitempty"`
+	AssetURL     string `json:"assetURL,omitempty"`
+	Orientation  string `json:"orientation,omitempty"`
+	SortPosition int32  `json:"sortPosition,omitempty"`
+	SourceHeight int32  `json:"sourceHeight,omitempty"`
+	SourceWidth  int32  `json:"sourceWidth,omitempty"`
+}
+
+// MediaAppPreviewOrScreenshots is the app previews and screenshots of a product page locale for a device size
+//
+// https://developer.apple.com/documentation/apple_search_ads/mediaapppreviewoscreenshots
+type MediaAppPreviewOrScreenshots struct {
+	AppPreviewDevice string                                `json:"appPreviewDevice,omitempty"`
+	AppPreviews      []*MediaAppPreviewOrScreenshotsDetail `json:"appPreviews,omitempty"`
+	Screenshots      []*MediaAppPreviewOrScreenshotsDetail `json:"screenshots,omitempty"`
+}
+
+// ProductPageLocaleDetail is the localized metadata of a custom product page
+//
+// https://developer.apple.com/documentation/apple_search_ads/productpagelocaledetail
+type ProductPageLocaleDetail struct {
+	AdamID                     int64                                    `json:"adamId,omitempty"`
+	AppName                    string                                   `json:"appName,omitempty"`
+	AppPreviewDeviceWithAssets map[string]*MediaAppPreviewOrScreenshots `json:"appPreviewDeviceWithAssets,omitempty"`
+	Language                   string                                   `json:"language,omitempty"`
+	LanguageCode               string                                   `json:"languageCode,omitempty"`
+	ProductPageID              string                                   `json:"productPageId,omitempty"`
+	PromotionalText            string                                   `json:"promotionalText,omitempty"`
+	ShortDescription           string                                   `json:"shortDescription,omitempty"`
+	SubTitle                   string                                   `json:"subTitle,omitempty"`
+}
+
+// GetProductPageLocalesQuery defines query parameter for GetProductPageLocales endpoint.
+type GetProductPageLocalesQuery struct {
+	// Expand returns the app preview and screenshot assets of each locale when true.
+	Expand bool `form:"expand,omitempty"`
+	// LanguageCode filters locales by language, such as "en-US".
+	LanguageCode string `form:"languageCode,omitempty"`
+}
+
+// ProductPageLocaleDetailListResponse is the response details of product page locale requests
+//
+// https://developer.apple.com/documentation/apple_search_ads/productpagelocaledetaillistresponse
+type ProductPageLocaleDetailListResponse struct {
+	Locales    []*ProductPageLocaleDetail `json:"data,omitempty"`
+	Error      *ErrorResponseBody         `json:"error,omitempty"`
+	Pagination *PageDetail                `json:"pagination,omitempty"`
+}
+
+// CountryOrRegion is a country or region where custom product pages are supported
+//
+// https://developer.apple.com/documentation/apple_search_ads/get_supported_countries_or_regions
+type CountryOrRegion struct {
+	CountryOrRegion string `json:"countryOrRegion,omitempty"`
+	Message         string `json:"message,omitempty"`
+}
+
+// GetSupportedCountriesOrRegionsQuery defines query parameter for GetSupportedCountriesOrRegions endpoint.
+type GetSupportedCountriesOrRegionsQuery struct {
+	// CountriesOrRegions is a comma-separated list of ISO alpha-2 country codes, such as "US,GB".
+	CountriesOrRegions string `form:"countriesOrRegions,omitempty"`
+}
+
+// CountryOrRegionListResponse is the response details of supported country or region requests
+type CountryOrRegionListResponse struct {
+	CountriesOrRegions []*CountryOrRegion `json:"data,omitempty"`
+	Error              *ErrorResponseBody `json:"error,omitempty"`
+}
+
+// AppPreviewDevicesResponse is the app preview device sizes keyed by device identifier
+//
+// https://developer.apple.com/documentation/apple_search_ads/apppreviewdevicesmappingresponse
+type AppPreviewDevicesResponse struct {
+	Devices map[string]string  `json:"data,omitempty"`
+	Error   *ErrorResponseBody `json:"error,omitempty"`
+}
+
+// CreativeState is the state of a creative.
+type CreativeState string
+
+const (
+	// CreativeStateValid is for a creative state on Valid.
+	CreativeStateValid CreativeState = "VALID"
+	// CreativeStateInvalid is for a creative state on Invalid.
+	CreativeStateInvalid CreativeState = "INVALID"
+)
+
+// Creative is a creative built from a custom product page that ads reference
+//
+// https://developer.apple.com/documentation/apple_search_ads/creative
+type Creative struct {
+	AdamID           int64          `json:"adamId,omitempty"`
+	CreationTime     DateTime       `json:"creationTime,omitempty"`
+	ID               int64          `json:"id,omitempty"`
+	ModificationTime DateTime       `json:"modificationTime,omitempty"`
+	Name             string         `json:"name,omitempty"`
+	OrgID            int64          `json:"orgId,omitempty"`
+	ProductPageID    string         `json:"productPageId,omitempty"`
+	State            CreativeState  `json:"state,omitempty"`
+	StateReasons     []string       `json:"stateReasons,omitempty"`
+	Type             AdCreativeType `json:"type,omitempty"`
+}
+
+// CreativeCreate is the request body to create a creative from a custom product page
+//
+// https://developer.apple.com/documentation/apple_search_ads/creativecreate
+type CreativeCreate struct {
+	AdamID        int64          `json:"adamId"`
+	Name          string         `json:"name"`
+	ProductPageID string         `json:"productPageId"`
+	Type          AdCreativeType `json:"type"`
+}
+
+// GetCreativeQuery defines query parameter for GetCreative endpoint.
+type GetCreativeQuery struct {
+	IncludeDeletedCreativeSetAssets bool `form:"includeDeletedCreativeSetAssets,omitempty"`
+}
+
+// GetAllCreativesQuery defines query parameter for GetAllCreatives endpoint.
+type GetAllCreativesQuery struct {
+	Limit  int32 `form:"limit,omitempty"`
+	Offset int32 `form:"offset,omitempty"`
+}
+
+// CreativeResponse is a container for the creative response body
+//
+// https://developer.apple.com/documentation/apple_search_ads/creativeresponse
+type CreativeResponse struct {
+	Creative   *Creative          `json:"data,omitempty"`
+	Error      *ErrorResponseBody `json:"error,omitempty"`
+	Pagination *PageDetail        `json:"pagination,omitempty"`
+}
+
+// CreativeListResponse is the response details of creative requests
+//
+// https://developer.apple.com/documentation/apple_search_ads/creativelistresponse
+type CreativeListResponse struct {
+	Creatives  []*Creative        `json:"data,omitempty"`
+	Error      *ErrorResponseBody `json:"error,omitempty"`
+	Pagination *PageDetail        `json:"pagination,omitempty"`
+}
+
 // SearchApps Searches for iOS apps to promote in a campaign
 //
 // https://developer.apple.com/documentation/apple_search_ads/search_for_ios_apps
 func (s *AppService) SearchApps(params *SearchAppsQuery) (*AppInfoListResponse, error) {
+	return s.SearchAppsWithContext(context.Background(), params)
+}
+
+// SearchAppsWithContext is like SearchApps but uses ctx to cancel the request or bound its deadline.
+func (s *AppService) SearchAppsWithContext(ctx context.Context, params *SearchAppsQuery) (*AppInfoListResponse, error) {
 	url := "search/apps"
 	res := new(AppInfoListResponse)
-	err := s.client.get(url, res, params)
+	err := s.client.get(ctx, url, res, params)
 
 	return res, err
 }
 
+// SearchAppsPager returns a Pager that walks all apps matching params page by page via SearchApps.
+// Limit and Offset in params set the page size and the starting offset.
+func (s *AppService) SearchAppsPager(params *SearchAppsQuery) *Pager[*AppInfo] {
+	query := SearchAppsQuery{}
+	if params != nil {
+		query = *params
+	}
+	p := NewPager(func(ctx context.Context, offset, limit int) ([]*AppInfo, *PageDetail, error) {
+		q := query
+		q.Offset, q.Limit = int32(offset), int32(limit)
+		res, err := s.SearchAppsWithContext(ctx, &q)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.AppInfos, res.Pagination, nil
+	})
+	return p.Offset(int(query.Offset)).PageSize(int(query.Limit))
+}
+
 // FindAppEligibilityRecords Fetches app eligibility records by adam ID.
 //
 // https://developer.apple.com/documentation/apple_search_ads/find_app_eligibility_records
 func (s *AppService) FindAppEligibilityRecords(adamId int64, selector *Selector) (*EligibilityRecordListResponse, error) {
+	return s.FindAppEligibilityRecordsWithContext(context.Background(), adamId, selector)
+}
+
+// FindAppEligibilityRecordsWithContext is like FindAppEligibilityRecords but uses ctx to cancel the request or bound its deadline.
+func (s *AppService) FindAppEligibilityRecordsWithContext(ctx context.Context, adamId int64, selector *Selector) (*EligibilityRecordListResponse, error) {
 	url := fmt.Sprintf("apps/%d/eligibilities/find", adamId)
 	res := new(EligibilityRecordListResponse)
-	err := s.client.post(url, res, selector)
+	err := s.client.find(ctx, url, res, selector)
 
 	return res, err
 }
+
+// FindAppEligibilityRecordsPager returns a Pager that walks all eligibility records matching selector page by page via FindAppEligibilityRecords.
+func (s *AppService) FindAppEligibilityRecordsPager(adamId int64, selector *Selector) *Pager[*EligibilityRecord] {
+	return newSelectorPager(selector, func(ctx context.Context, offset, limit int) ([]*EligibilityRecord, *PageDetail, error) {
+		res, err := s.FindAppEligibilityRecordsWithContext(ctx, adamId, pageSelector(selector, offset, limit))
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.EligibilityRecords, res.Pagination, nil
+	})
+}
@@ -1,10 +1,16 @@
 package asa
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/ropon/requests/v2"
@@ -18,14 +24,27 @@ const (
 	userAgent             = "apple-search-api-go"
 )
 
-// Client 客户端
+// Client 客户端, 可以在多个goroutine中并发使用
 type Client struct {
-	auth   *TokenConfig
-	client *requests.Request
-	common service
+	mu          sync.RWMutex
+	auth        *TokenConfig
+	tokenSource TokenSource
+	client      *requests.Request
+	httpClient  *http.Client
+	baseURL     *url.URL
+	userAgent   string
+	orgID       int64
+	scoped      bool
+	retryPolicy *RetryPolicy
+	rateLimiter RateLimiter
+	middlewares []Middleware
+	callHooks   []CallHook
+	common      service
 
 	Campaigns         *CampaignService
 	AdGroups          *AdGroupService
+	Ads               *AdService
+	Creatives         *CreativeService
 	Reporting         *ReportingService
 	Keywords          *KeywordService
 	AccessControlList *AccessControlListService
@@ -47,6 +66,8 @@ func SetDefault(req *requests.Request) {
 }
 
 // NewClient 创建客户端
+//
+// 传入 *requests.Request 时, 接口请求使用其代理、TLS、超时配置以及 SetHeader 设置的请求头。
 func NewClient(httpClient interface{}, accessToken ...string) *Client {
 	var c *Client
 
@@ -62,6 +83,7 @@ func NewClient(httpClient interface{}, accessToken ...string) *Client {
 		}
 		if len(accessToken) > 0 {
 			c.client.SetHeader("Authorization", fmt.Sprintf("Bearer %s", accessToken[0]))
+			c.tokenSource = StaticTokenSource(accessToken[0])
 		}
 
 	case *TokenConfig:
@@ -72,9 +94,11 @@ func NewClient(httpClient interface{}, accessToken ...string) *Client {
 		if err != nil {
 			return nil
 		}
+		// 不保存http.Client, 每次调用读取 TokenConfig 当前的http.Client, 使之后的代理等配置对客户端生效
 		c = &Client{
-			auth:   v,
-			client: httpReq,
+			auth:        v,
+			tokenSource: v,
+			client:      httpReq,
 		}
 
 	case nil:
@@ -85,58 +109,122 @@ func NewClient(httpClient interface{}, accessToken ...string) *Client {
 		SetDefault(req)
 		req.SetHeader("Authorization", fmt.Sprintf("Bearer %s", accessToken[0]))
 		c = &Client{
-			client: req,
+			client:      req,
+			tokenSource: StaticTokenSource(accessToken[0]),
 		}
 
 	default:
 		return nil
 	}
 
-	// 初始化各个服务
+	c.retryPolicy = DefaultRetryPolicy()
+	c.initServices()
+	return c
+}
+
+// initServices 初始化各个服务
+func (c *Client) initServices() {
 	c.common.client = c
 	c.Campaigns = (*CampaignService)(&c.common)
 	c.AdGroups = (*AdGroupService)(&c.common)
+	c.Ads = (*AdService)(&c.common)
+	c.Creatives = (*CreativeService)(&c.common)
 	c.Reporting = (*ReportingService)(&c.common)
 	c.Keywords = (*KeywordService)(&c.common)
 	c.AccessControlList = (*AccessControlListService)(&c.common)
 	c.App = (*AppService)(&c.common)
 	c.Geo = (*GeoService)(&c.common)
-
-	return c
 }
 
 // SetHTTPTimeout 设置http请求超时时间
 func (c *Client) SetHTTPTimeout(n time.Duration) error {
-	client, err := c.HttpClient()
-	if err != nil {
-		return err
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sharesAuthRequest() {
+		c.auth.setHTTPTimeout(n)
+		return nil
 	}
-	client.SetTimeout(n)
-	return nil
+	if c.client == nil {
+		return errors.New("client not initialized")
+	}
+	c.client.SetTimeout(n)
+	return c.updateHTTPClient(func(httpClient *http.Client) {
+		httpClient.Timeout = n
+	})
 }
 
 // SetHTTPDebug 设置http请求debug
 func (c *Client) SetHTTPDebug(flag bool) error {
-	client, err := c.HttpClient()
-	if err != nil {
-		return err
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sharesAuthRequest() {
+		c.auth.SetHTTPDebug(flag)
+		return nil
+	}
+	if c.client == nil {
+		return errors.New("client not initialized")
 	}
-	client.Debug = flag
+	c.client.Debug = flag
 	return nil
 }
 
 // SetHTTPProxy 设置http请求代理
 func (c *Client) SetHTTPProxy(proxyUrl string) error {
-	client, err := c.HttpClient()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sharesAuthRequest() {
+		return c.auth.SetHTTPProxy(proxyUrl)
+	}
+	transport, err := proxyTransport(proxyUrl)
 	if err != nil {
 		return err
 	}
-	client.SetProxy(proxyUrl)
+	if c.client == nil {
+		return errors.New("client not initialized")
+	}
+	c.client.SetProxy(proxyUrl)
+	return c.updateHTTPClient(func(httpClient *http.Client) {
+		httpClient.Transport = transport
+	})
+}
+
+// sharesAuthRequest 判断客户端是否与 TokenConfig 共用请求配置
+//
+// NewClient 使用 TokenConfig 创建的客户端与其共用 requests.Request 与http.Client, 之后在任意一方
+// 修改的代理、超时、debug配置对双方都生效; 读写这些配置需要持有 TokenConfig 的锁。
+func (c *Client) sharesAuthRequest() bool {
+	return c.auth != nil && c.httpClient == nil
+}
+
+// updateHTTPClient 修改接口请求使用的http.Client, 调用方需持有锁, 只用于不与 TokenConfig 共用配置的客户端
+//
+// 使用 requests.Request 的http.Client时直接修改, 每次调用都会复制; 否则复制后替换, 不影响正在进行的请求。
+func (c *Client) updateHTTPClient(fn func(httpClient *http.Client)) error {
+	if c.httpClient == nil {
+		httpClient, err := requestHTTPClient(c.client)
+		if err != nil {
+			return err
+		}
+		fn(httpClient)
+		return nil
+	}
+	httpClient := *c.httpClient
+	fn(&httpClient)
+	c.httpClient = &httpClient
 	return nil
 }
 
 // SetOrgID 设置组织ID
 func (c *Client) SetOrgID(orgID int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// ForOrg 创建的子客户端只修改自身的组织ID
+	if c.scoped {
+		c.orgID = orgID
+		return nil
+	}
+
 	// 如果有 auth 配置,直接设置
 	if c.auth != nil {
 		c.auth.SetOrgID(orgID)
@@ -147,6 +235,7 @@ func (c *Client) SetOrgID(orgID int64) error {
 	if c.client != nil {
 		// 更新或添加 X-AP-Context header
 		c.client.SetHeader("X-AP-Context", fmt.Sprintf("orgId=%v", orgID))
+		c.orgID = orgID
 		return nil
 	}
 
@@ -155,186 +244,350 @@ func (c *Client) SetOrgID(orgID int64) error {
 
 // HttpClient 获取请求客户端
 func (c *Client) HttpClient() (*requests.Request, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 与 TokenConfig 共用请求时, 由 TokenConfig 在锁内设置认证头
+	if c.sharesAuthRequest() {
+		client, err := c.auth.Client()
+		if err != nil {
+			return nil, err
+		}
+		c.client = client
+		return client, nil
+	}
+
 	// 如果client为空,需要初始化
 	if c.client == nil {
-		// 优先使用TokenConfig方式
-		if c.auth != nil {
-			client, err := c.auth.Client()
-			if err != nil {
-				return nil, err
-			}
-			c.client = client
-		} else {
-			// 默认初始化
-			c.client = requests.New()
-		}
+		c.client = requests.New()
 	}
 
 	// 设置默认配置
 	SetDefault(c.client)
+	// 注意:非auth方式的orgID已经在SetOrgID中设置了header,这里不需要重复设置
+	return c.client, nil
+}
 
-	// 如果有auth配置,需要设置认证头
-	if c.auth != nil {
-		var err error
-		c.client, err = c.auth.Client()
+// settings 单次调用使用的客户端配置快照
+type settings struct {
+	httpClient  *http.Client
+	baseURL     *url.URL
+	userAgent   string
+	header      http.Header
+	debug       bool
+	auth        *TokenConfig
+	tokenSource TokenSource
+	orgID       int64
+	retryPolicy *RetryPolicy
+	rateLimiter RateLimiter
+	middlewares []Middleware
+	callHooks   []CallHook
+}
+
+// settings 获取当前配置快照, 保证一次调用(含重试)使用一致的配置
+func (c *Client) settings() (*settings, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	s := &settings{
+		httpClient:  c.httpClient,
+		baseURL:     c.baseURL,
+		userAgent:   c.userAgent,
+		auth:        c.auth,
+		tokenSource: c.tokenSource,
+		orgID:       c.orgID,
+		retryPolicy: c.retryPolicy,
+		rateLimiter: c.rateLimiter,
+		middlewares: c.middlewares,
+		callHooks:   c.callHooks,
+	}
+	cfg, err := c.requestConfig()
+	if err != nil {
+		return nil, err
+	}
+	s.httpClient, s.header, s.debug = cfg.httpClient, cfg.header, cfg.debug
+	if s.baseURL == nil {
+		s.baseURL = cfg.baseURL
+	}
+	if s.baseURL == nil {
+		baseURL, err := url.Parse(defaultBaseURL)
 		if err != nil {
 			return nil, err
 		}
+		s.baseURL = baseURL
 	}
-	// 注意:非auth方式的orgID已经在SetOrgID中设置了header,这里不需要重复设置
-	return c.client, nil
+	if s.auth != nil && !c.scoped {
+		s.orgID = s.auth.currentOrgID()
+	}
+	return s, nil
 }
 
-// rawJson 处理json响应
-func (c *Client) rawJson(res *requests.Response, resp interface{}) error {
-	if res.Json().Get("code").Int64() != 0 {
-		return errors.New(res.Json().Get("message").String())
+// requestConfig 获取接口请求使用的请求头、debug与http.Client等配置, 调用方需持有锁
+//
+// 与 TokenConfig 共用配置时在 TokenConfig 的锁内读取; 客户端自身设置了http.Client时优先使用。
+func (c *Client) requestConfig() (*requestConfig, error) {
+	if c.sharesAuthRequest() {
+		return c.auth.requestConfig()
+	}
+	if c.client == nil {
+		return nil, errors.New("client not initialized")
 	}
-	err := res.RawJson(resp)
+	cfg, err := readRequestConfig(c.client)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	if c.httpClient != nil {
+		cfg.httpClient = c.httpClient
+	}
+	return cfg, nil
 }
 
-// get 处理get请求
-func (c *Client) get(apiUrl string, resp interface{}, params ...interface{}) error {
-	if len(params) > 0 {
-		param := params[0]
-		// 构建 URL
-		u, err := url.Parse(apiUrl)
+// newRequest 构建携带上下文的http请求
+func (c *Client) newRequest(ctx context.Context, s *settings, method, apiUrl string, body []byte) (*http.Request, error) {
+	u, err := s.baseURL.Parse(apiUrl)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.header {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	// WithUserAgent 优先, 否则使用请求头中的User-Agent
+	if s.userAgent != "" {
+		req.Header.Set("User-Agent", s.userAgent)
+	} else if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	if s.tokenSource != nil {
+		token, err := s.tokenSource.Token(ctx)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		if token == nil || token.AccessToken == "" {
+			return nil, errEmptyToken
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
+	}
+	if s.orgID > 0 {
+		req.Header.Set("X-AP-Context", fmt.Sprintf("orgId=%d", s.orgID))
+	}
+	return req, nil
+}
 
-		query := u.Query()
-		err = addParamsToQuery(query, param)
+// do 执行请求并解析json响应,ctx取消或超时时中断请求
+func (c *Client) do(ctx context.Context, method, apiUrl string, resp interface{}, data ...interface{}) error {
+	var body []byte
+	if len(data) > 0 {
+		bS, err := json.Marshal(data[0])
 		if err != nil {
 			return err
 		}
-		u.RawQuery = query.Encode()
-		apiUrl = u.String()
+		body = bS
 	}
-	client, err := c.HttpClient()
+	s, err := c.settings()
 	if err != nil {
 		return err
 	}
-	res, err := client.Get(apiUrl)
-	if err != nil {
-		return err
+	if orgID, ok := orgIDFromContext(ctx); ok {
+		s.orgID = orgID
 	}
-	return c.rawJson(res, resp)
-}
 
-// post 处理post请求
-func (c *Client) post(url string, resp interface{}, data ...interface{}) error {
-	client, err := c.HttpClient()
-	if err != nil {
-		return err
+	info := &CallInfo{Method: method, URL: apiUrl, OrgID: s.orgID}
+	// 只在注册了回调时解析调用栈, 避免无回调时的额外开销
+	if len(s.callHooks) > 0 {
+		info.Service, info.Operation = callerOperation()
 	}
-	if len(data) > 0 {
-		postData := data[0]
-		bS, err := json.Marshal(postData)
-		if err != nil {
-			return err
-		}
-		res, err := client.Post(url, string(bS))
-		if err != nil {
-			return err
+	// hookCtx[i] 为第i个回调返回的ctx, AfterCall 收到与自身 BeforeCall 对应的ctx
+	hookCtx := make([]context.Context, len(s.callHooks))
+	for i, h := range s.callHooks {
+		if h.BeforeCall != nil {
+			ctx = h.BeforeCall(ctx, info)
 		}
-		return c.rawJson(res, resp)
+		hookCtx[i] = ctx
 	}
-	res, err := client.Post(url, data...)
-	if err != nil {
-		return err
+	start := time.Now()
+	err = c.call(ctx, s, info, method, apiUrl, body, resp)
+	info.Latency = time.Since(start)
+	info.Err = err
+	for i := len(s.callHooks) - 1; i >= 0; i-- {
+		if after := s.callHooks[i].AfterCall; after != nil {
+			after(hookCtx[i], info)
+		}
 	}
-	return c.rawJson(res, resp)
+	return err
 }
 
-// postWithQuery 处理带query的post请求
-func (c *Client) postWithQuery(apiUrl string, resp, param interface{}, data ...interface{}) error {
-	// 构建 URL
-	u, err := url.Parse(apiUrl)
+// call 按重试策略发送请求并解析响应, 同时记录尝试次数与最终状态码
+func (c *Client) call(ctx context.Context, s *settings, info *CallInfo, method, apiUrl string, body []byte, resp interface{}) error {
+	var (
+		res     *http.Response
+		content []byte
+	)
+	err := c.retry(ctx, s.retryPolicy, method, func() (*http.Response, error) {
+		var err error
+		info.Attempts++
+		res, content, err = c.send(ctx, s, method, apiUrl, body, info.Attempts)
+		if res != nil {
+			info.StatusCode = res.StatusCode
+			if res.StatusCode == http.StatusTooManyRequests {
+				info.RateLimited++
+			}
+		}
+		return res, err
+	})
 	if err != nil {
 		return err
 	}
+	return c.rawJson(res, content, resp)
+}
 
-	query := u.Query()
-	err = addParamsToQuery(query, param)
-	if err != nil {
-		return err
+// send 发送单次请求, 依次经过限流器与中间件
+func (c *Client) send(ctx context.Context, s *settings, method, apiUrl string, body []byte, attempt int) (*http.Response, []byte, error) {
+	if s.rateLimiter != nil {
+		if err := s.rateLimiter.Wait(ctx, s.orgID); err != nil {
+			return nil, nil, err
+		}
 	}
-	u.RawQuery = query.Encode()
-	apiUrl = u.String()
-	client, err := c.HttpClient()
+	req, err := c.newRequest(ctx, s, method, apiUrl, body)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	if len(data) > 0 {
-		postData := data[0]
-		bS, err := json.Marshal(postData)
-		if err != nil {
-			return err
+
+	info := &RequestInfo{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Header:  req.Header,
+		Body:    body,
+		OrgID:   s.orgID,
+		Attempt: attempt,
+	}
+	for _, mw := range s.middlewares {
+		if mw.BeforeRequest == nil {
+			continue
 		}
-		res, err := client.Post(apiUrl, string(bS))
-		if err != nil {
-			return err
+		if err := mw.BeforeRequest(ctx, info); err != nil {
+			return nil, nil, err
 		}
-		return c.rawJson(res, resp)
 	}
-	res, err := client.Post(apiUrl, data...)
-	if err != nil {
-		return err
+
+	start := time.Now()
+	res, content, err := roundTrip(s.httpClient, req, s.debug)
+	if s.rateLimiter != nil && res != nil {
+		s.rateLimiter.Observe(s.orgID, res.StatusCode)
 	}
-	return c.rawJson(res, resp)
+
+	result := &ResponseInfo{Request: info, Latency: time.Since(start), Err: err}
+	if res != nil {
+		result.StatusCode = res.StatusCode
+		result.Header = res.Header
+		result.Body = content
+	}
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		if after := s.middlewares[i].AfterResponse; after != nil {
+			after(ctx, result)
+		}
+	}
+	return res, content, err
 }
 
-// put 处理put请求
-func (c *Client) put(url string, resp interface{}, data ...interface{}) error {
-	client, err := c.HttpClient()
+// roundTrip 执行http请求并读取响应内容, debug为true时打印脱敏后的请求与响应
+func roundTrip(httpClient *http.Client, req *http.Request, debug bool) (*http.Response, []byte, error) {
+	if debug {
+		if dump, err := httputil.DumpRequestOut(req, true); err == nil {
+			fmt.Println("===========Go RequestDebug ============")
+			fmt.Printf("\n%s\n", redactText(string(dump)))
+			fmt.Println("===========End RequestDebug============")
+		}
+	}
+	res, err := httpClient.Do(req)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	if len(data) > 0 {
-		postData := data[0]
-		bS, err := json.Marshal(postData)
-		if err != nil {
-			return err
-		}
-		res, err := client.Put(url, string(bS))
-		if err != nil {
-			return err
+	defer res.Body.Close()
+	if debug {
+		if dump, err := httputil.DumpResponse(res, true); err == nil {
+			fmt.Println("===========Go ResponseDebug ===========")
+			fmt.Printf("\n%s\n", redactText(string(dump)))
+			fmt.Println("===========End ResponseDebug===========")
 		}
-		return c.rawJson(res, resp)
 	}
-	res, err := client.Put(url, data...)
+	content, err := io.ReadAll(res.Body)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	return c.rawJson(res, resp)
+	return res, content, nil
 }
 
-// delete 处理delete请求
-func (c *Client) delete(url string, resp interface{}, data ...interface{}) error {
-	client, err := c.HttpClient()
+// rawJson 处理json响应, 失败时返回 *APIError
+func (c *Client) rawJson(res *http.Response, content []byte, resp interface{}) error {
+	if apiErr := newAPIError(res, content); apiErr != nil {
+		return apiErr
+	}
+	if len(content) == 0 {
+		return nil
+	}
+	return json.Unmarshal(content, resp)
+}
+
+// withQuery 将查询参数拼接到url
+func withQuery(apiUrl string, param interface{}) (string, error) {
+	u, err := url.Parse(apiUrl)
 	if err != nil {
-		return err
+		return "", err
 	}
-	if len(data) > 0 {
-		postData := data[0]
-		bS, err := json.Marshal(postData)
-		if err != nil {
-			return err
-		}
-		res, err := client.Delete(url, string(bS))
+
+	query := u.Query()
+	err = addParamsToQuery(query, param)
+	if err != nil {
+		return "", err
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// get 处理get请求
+func (c *Client) get(ctx context.Context, apiUrl string, resp interface{}, params ...interface{}) error {
+	// 可选的查询参数为nil时不拼接查询参数
+	if len(params) > 0 && !isNilPointer(params[0]) {
+		var err error
+		apiUrl, err = withQuery(apiUrl, params[0])
 		if err != nil {
 			return err
 		}
-		return c.rawJson(res, resp)
 	}
-	res, err := client.Delete(url, data...)
+	return c.do(ctx, http.MethodGet, apiUrl, resp)
+}
+
+// post 处理post请求
+func (c *Client) post(ctx context.Context, url string, resp interface{}, data ...interface{}) error {
+	return c.do(ctx, http.MethodPost, url, resp, data...)
+}
+
+// postWithQuery 处理带query的post请求
+func (c *Client) postWithQuery(ctx context.Context, apiUrl string, resp, param interface{}, data ...interface{}) error {
+	apiUrl, err := withQuery(apiUrl, param)
 	if err != nil {
 		return err
 	}
-	return c.rawJson(res, resp)
+	return c.do(ctx, http.MethodPost, apiUrl, resp, data...)
+}
+
+// find 处理只读的POST查询(Find*与报表), 可按重试策略重放
+func (c *Client) find(ctx context.Context, url string, resp interface{}, data ...interface{}) error {
+	return c.do(withFind(ctx), http.MethodPost, url, resp, data...)
+}
+
+// put 处理put请求
+func (c *Client) put(ctx context.Context, url string, resp interface{}, data ...interface{}) error {
+	return c.do(ctx, http.MethodPut, url, resp, data...)
+}
+
+// delete 处理delete请求
+func (c *Client) delete(ctx context.Context, url string, resp interface{}, data ...interface{}) error {
+	return c.do(ctx, http.MethodDelete, url, resp, data...)
 }
@@ -1,8 +1,16 @@
 package asa
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/ropon/requests/v2"
 )
 
 // go test -v -run TestNewClient
@@ -18,3 +26,186 @@ func TestNewClient(t *testing.T) {
 	assert.True(t, c.client.Debug)
 
 }
+
+// newTestClient 创建指向本地测试服务的客户端
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c := NewClient(nil, "token")
+	assert.NoError(t, c.client.SetBaseUrl(srv.URL+"/"))
+	return c
+}
+
+// go test -v -run TestClientWithContextCanceled
+func TestClientWithContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.Campaigns.GetAllCampaignsWithContext(ctx, &GetAllCampaignQuery{Limit: 2})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// go test -v -race -run TestClientConcurrentUse
+func TestClientConcurrentUse(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, c.SetOrgID(int64(i)))
+			assert.NoError(t, c.SetHTTPTimeout(time.Duration(i+1)*time.Second))
+			assert.NoError(t, c.SetHTTPDebug(false))
+			c.SetRetryPolicy(DefaultRetryPolicy())
+			res, err := c.Campaigns.GetCampaign(1)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), res.Campaign.ID)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// go test -v -race -run TestTokenClientConcurrentUse
+func TestTokenClientConcurrentUse(t *testing.T) {
+	t.Parallel()
+
+	auth := newTestTokenConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token":"abc","expires_in":3600}`))
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer abc", r.Header.Get("Authorization"))
+		assert.Contains(t, r.Header.Get("X-AP-Context"), "orgId=")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(auth)
+	assert.NoError(t, c.client.SetBaseUrl(srv.URL+"/"))
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, c.SetOrgID(int64(i)))
+			_, err := c.AccessControlList.GetUserACL()
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// go test -v -race -run TestTokenClientProxyChange
+func TestTokenClientProxyChange(t *testing.T) {
+	t.Parallel()
+
+	auth := newTestTokenConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token":"abc","expires_in":3600}`))
+	})
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+		assert.Equal(t, "api.example", r.URL.Host)
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	defer proxy.Close()
+
+	c := NewClient(auth)
+	assert.NoError(t, c.client.SetBaseUrl("http://api.example/"))
+
+	// 创建客户端之后修改 TokenConfig 的代理同样生效
+	assert.NoError(t, auth.SetHTTPProxy(proxy.URL))
+	_, err := c.Campaigns.GetCampaign(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&proxied))
+
+	// 客户端上修改的超时同样作用于 TokenConfig
+	assert.NoError(t, c.SetHTTPTimeout(time.Second))
+	cfg, err := auth.requestConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, cfg.httpClient.Timeout)
+	_, err = c.ForOrg(2).Campaigns.GetCampaign(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&proxied))
+}
+
+// go test -v -race -run TestTokenClientConcurrentConfig
+func TestTokenClientConcurrentConfig(t *testing.T) {
+	t.Parallel()
+
+	auth := newTestTokenConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token":"abc","expires_in":3600}`))
+	})
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	defer api.Close()
+
+	c := NewClient(auth)
+	assert.NoError(t, c.client.SetBaseUrl(api.URL+"/"))
+
+	// 接口请求与 TokenConfig、客户端上的配置修改并发进行
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			_, err := c.Campaigns.GetCampaign(1)
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			auth.SetHTTPDebug(false)
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, c.SetHTTPDebug(false))
+			assert.NoError(t, c.SetHTTPTimeout(defaultTimeout))
+		}()
+	}
+	wg.Wait()
+}
+
+// go test -v -run TestNewClientWithRequest
+func TestNewClientWithRequest(t *testing.T) {
+	t.Parallel()
+
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+		assert.Equal(t, "api.example", r.URL.Host)
+		assert.Equal(t, "Bearer manual", r.Header.Get("Authorization"))
+		assert.Equal(t, "value", r.Header.Get("X-Custom"))
+		assert.Equal(t, "custom-agent", r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	defer proxy.Close()
+
+	req := requests.New()
+	c := NewClient(req)
+	// 创建客户端之后通过 requests.Request 设置的请求头与代理同样生效
+	req.SetHeader("Authorization", "Bearer manual")
+	req.SetHeader("X-Custom", "value")
+	req.SetHeader("User-Agent", "custom-agent")
+	req.SetProxy(proxy.URL)
+	assert.NoError(t, req.SetBaseUrl("http://api.example/"))
+
+	_, err := c.Campaigns.GetCampaign(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&proxied))
+
+	req.SetTimeout(time.Nanosecond)
+	_, err = c.Campaigns.GetCampaign(1)
+	assert.Error(t, err)
+}
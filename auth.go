@@ -1,10 +1,18 @@
 package asa
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/dgrijalva/jwt-go/v4"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/ropon/requests/v2"
@@ -15,8 +23,10 @@ var ErrMissingPEM = errors.New("no PEM blob found")
 
 // TokenConfig 获取token配置
 type TokenConfig struct {
+	mu           sync.Mutex
 	jwtGenerator *standardJWTGenerator
 	httpReq      *requests.Request
+	httpClient   *http.Client
 	orgID        int64
 }
 
@@ -35,66 +45,229 @@ type standardJWTGenerator struct {
 	clientID       string
 	expireDuration time.Duration
 	privateKey     *ecdsa.PrivateKey
-	accessToken    *accessToken
-	token          string
-	httpReq        *requests.Request
+	// transport 返回换取token使用的http.Client以及是否打印debug信息
+	transport func() (*http.Client, bool)
+
+	// mu 保护以下字段, refreshing 非空时表示正在换取access token
+	mu            sync.Mutex
+	accessToken   *accessToken
+	token         string
+	refreshing    chan struct{}
+	hooks         []TokenHook
+	cache         TokenCache
+	refreshBefore time.Duration
+	authURL       string
+	// secret 当前client secret的签发信息
+	secret           ClientSecretInfo
+	secretWarnBefore time.Duration
+	secretWarned     bool
+	onSecretExpiry   func(info ClientSecretInfo)
+	// generation 私钥版本, RotateKey 时递增, 用于丢弃使用旧私钥换取的access token
+	generation uint64
 }
 
-func (g *standardJWTGenerator) AccessToken() (string, error) {
-	if g.IsAccessTokenValid() {
-		return g.accessToken.AccessToken, nil
-	}
+// TokenExchangeInfo 一次access token换取的信息, 不包含client secret与access token
+type TokenExchangeInfo struct {
+	// ClientID 换取token使用的client id
+	ClientID string
+	// URL 请求地址, 敏感参数已脱敏
+	URL string
+	// StatusCode http状态码, 请求失败时为0
+	StatusCode int
+	// ExpiresIn access token有效期
+	ExpiresIn time.Duration
+	// Latency 请求耗时
+	Latency time.Duration
+	// Err 换取失败的错误
+	Err error
+}
 
-	token, err := g.Token()
+// TokenHook access token换取的回调, 同一次换取中两个回调收到的是同一个 *TokenExchangeInfo
+type TokenHook struct {
+	// BeforeExchange 换取前调用, 返回的ctx用于换取请求
+	BeforeExchange func(ctx context.Context, info *TokenExchangeInfo) context.Context
+	// AfterExchange 换取完成后调用
+	AfterExchange func(ctx context.Context, info *TokenExchangeInfo)
+}
+
+// AccessToken 获取access token, 并发调用时同一时刻只有一个请求去换取新token
+func (g *standardJWTGenerator) AccessToken(ctx context.Context) (string, error) {
+	accToken, err := g.currentAccessToken(ctx)
 	if err != nil {
 		return "", err
 	}
+	return accToken.AccessToken, nil
+}
+
+// currentAccessToken 获取未过期的access token, 必要时换取新token
+func (g *standardJWTGenerator) currentAccessToken(ctx context.Context) (*accessToken, error) {
+	for {
+		g.mu.Lock()
+		if g.isAccessTokenValid() {
+			accToken := g.accessToken
+			g.mu.Unlock()
+			return accToken, nil
+		}
+		if wait := g.refreshing; wait != nil {
+			g.mu.Unlock()
+			select {
+			case <-wait:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		done := make(chan struct{})
+		g.refreshing = done
+		gen, key := g.generation, g.cacheKey()
+		g.mu.Unlock()
+
+		accessTkn, err := g.refreshAccessToken(ctx, gen, key)
+
+		g.mu.Lock()
+		// 换取期间私钥已更换, 丢弃旧私钥换取的token并使用新私钥重新换取
+		stale := g.generation != gen
+		if err == nil && !stale {
+			g.accessToken = accessTkn
+		}
+		g.refreshing = nil
+		g.mu.Unlock()
+		close(done)
+
+		if stale {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return accessTkn, nil
+	}
+}
+
+// refreshAccessToken 从缓存读取或换取新的access token, gen与key为开始换取时的私钥版本与缓存key
+func (g *standardJWTGenerator) refreshAccessToken(ctx context.Context, gen uint64, key string) (*accessToken, error) {
+	g.mu.Lock()
+	hooks, cache, refreshBefore := g.hooks, g.cache, g.refreshBefore
+	g.mu.Unlock()
+
+	if cache != nil {
+		if accToken := loadCachedAccessToken(ctx, cache, key, refreshBefore); accToken != nil {
+			return accToken, nil
+		}
+	}
 
-	accessTkn, err := g.generateAccessToken(token)
+	token, err := g.Token()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	g.accessToken = accessTkn
+	info := &TokenExchangeInfo{ClientID: g.clientID}
+	hookCtx := make([]context.Context, len(hooks))
+	for i, h := range hooks {
+		if h.BeforeExchange != nil {
+			ctx = h.BeforeExchange(ctx, info)
+		}
+		hookCtx[i] = ctx
+	}
+	start := time.Now()
+	accToken, err := g.generateAccessToken(ctx, token, info)
+	info.Latency = time.Since(start)
+	info.Err = err
+	if accToken != nil {
+		info.ExpiresIn = time.Duration(accToken.ExpiresIn) * time.Second
+	}
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if hooks[i].AfterExchange != nil {
+			hooks[i].AfterExchange(hookCtx[i], info)
+		}
+	}
+	if err == nil && cache != nil && g.currentGeneration() == gen {
+		// 缓存写入失败时仍使用新换取的token
+		_ = cache.Store(ctx, key, &Token{AccessToken: accToken.AccessToken, Expiry: accToken.expiresAfter})
+	}
+	return accToken, err
+}
 
-	return accessTkn.AccessToken, nil
+// currentGeneration 获取当前私钥版本
+func (g *standardJWTGenerator) currentGeneration() uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.generation
 }
 
 func (g *standardJWTGenerator) IsAccessTokenValid() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.isAccessTokenValid()
+}
+
+func (g *standardJWTGenerator) isAccessTokenValid() bool {
 	if g.accessToken == nil || g.accessToken.AccessToken == "" {
 		return false
 	}
 
-	if g.accessToken.expiresAfter.Before(time.Now()) {
+	// 过期前 refreshBefore 内视为无效, 提前换取新token
+	if !time.Now().Add(g.refreshBefore).Before(g.accessToken.expiresAfter) {
 		return false
 	}
 
 	return true
 }
 
-func (g *standardJWTGenerator) generateAccessToken(token string) (*accessToken, error) {
-	url := fmt.Sprintf("%s/oauth2/token?grant_type=client_credentials&client_id=%s&client_secret=%s&scope=searchadsorg", defaultAuthURL, g.clientID, token)
-	res, err := requests.Post(url)
+func (g *standardJWTGenerator) generateAccessToken(ctx context.Context, token string, info *TokenExchangeInfo) (*accessToken, error) {
+	g.mu.Lock()
+	authURL := g.authURL
+	g.mu.Unlock()
+	info.URL = authURL
+
+	// 凭证放在表单中, 避免client secret出现在url与访问日志里
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {g.clientID},
+		"client_secret": {token},
+		"scope":         {"searchadsorg"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, authURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", userAgent)
 
+	httpClient, debug := g.transport()
+	res, content, err := roundTrip(httpClient, req, debug)
 	if err != nil {
 		return nil, err
 	}
+	info.StatusCode = res.StatusCode
+	if oauthErr := newOAuthError(res, content); oauthErr != nil {
+		return nil, oauthErr
+	}
 
 	accToken := &accessToken{}
-	err = res.RawJson(accToken)
+	err = json.Unmarshal(content, accToken)
 	if err != nil {
 		return nil, err
 	}
+	if accToken.AccessToken == "" {
+		return nil, &OAuthError{StatusCode: res.StatusCode, Code: "invalid_response", Description: "empty access_token", Body: content}
+	}
 	accToken.expiresAfter = time.Now().Add(time.Second * time.Duration(accToken.ExpiresIn))
 	return accToken, nil
 }
 
 func (g *standardJWTGenerator) Token() (string, error) {
-	if g.IsTokenValid() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.isTokenValid() {
+		g.checkSecretExpiry()
 		return g.token, nil
 	}
 
-	t := jwt.NewWithClaims(jwt.SigningMethodES256, g.claims())
+	now := time.Now()
+	expiry := now.Add(g.expireDuration)
+	t := jwt.NewWithClaims(jwt.SigningMethodES256, g.claims(now, expiry))
 	t.Header["kid"] = g.keyID
 
 	token, err := t.SignedString(g.privateKey)
@@ -103,82 +276,229 @@ func (g *standardJWTGenerator) Token() (string, error) {
 	}
 
 	g.token = token
+	g.secret = ClientSecretInfo{KeyID: g.keyID, IssuedAt: now, ExpiresAt: expiry}
+	g.secretWarned = false
+	g.checkSecretExpiry()
 
 	return token, nil
 }
 
 func (g *standardJWTGenerator) IsTokenValid() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.isTokenValid()
+}
+
+// isTokenValid 判断client secret是否可用, 只比较签发时记录的过期时间, 不重新解析token
+func (g *standardJWTGenerator) isTokenValid() bool {
 	if g.token == "" {
 		return false
 	}
+	return time.Now().Add(clientSecretExpiryMargin).Before(g.secret.ExpiresAt)
+}
 
-	parsed, err := jwt.Parse(
-		g.token,
-		jwt.KnownKeyfunc(jwt.SigningMethodES256, g.privateKey),
-		jwt.WithAudience("https://appleid.apple.com"),
-		jwt.WithIssuer(g.issuerID),
-	)
-	if err != nil {
-		return false
+// checkSecretExpiry client secret即将过期时调用回调, 每个client secret只提醒一次, 调用方需持有锁
+func (g *standardJWTGenerator) checkSecretExpiry() {
+	if g.onSecretExpiry == nil || g.secretWarned || g.token == "" {
+		return
 	}
-
-	return parsed.Valid
+	if time.Now().Add(g.secretWarnBefore).Before(g.secret.ExpiresAt) {
+		return
+	}
+	g.secretWarned = true
+	// 在新的goroutine中调用, 回调中可以安全地调用 TokenConfig 的方法
+	go g.onSecretExpiry(g.secret)
 }
 
-func (g *standardJWTGenerator) claims() jwt.Claims {
-	expiry := time.Now().Add(g.expireDuration)
-
+func (g *standardJWTGenerator) claims(issuedAt, expiry time.Time) jwt.Claims {
 	return jwt.StandardClaims{
 		Audience:  jwt.ClaimStrings{"https://appleid.apple.com"},
 		Subject:   g.clientID,
 		Issuer:    g.issuerID,
+		IssuedAt:  jwt.At(issuedAt),
 		ExpiresAt: jwt.At(expiry),
 	}
 }
 
-// NewTokenConfig 创建token配置
+// NewTokenConfig 创建token配置, privateKey 支持的格式同 ParsePrivateKey
 func NewTokenConfig(clientID, teamID, keyID, privateKey string) (*TokenConfig, error) {
-	key, err := parsePrivateKey([]byte(privateKey))
+	key, err := ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenConfigFromKey(clientID, teamID, keyID, key)
+}
+
+// NewTokenConfigFromFile 使用私钥文件创建token配置
+func NewTokenConfigFromFile(clientID, teamID, keyID, path string) (*TokenConfig, error) {
+	key, err := LoadPrivateKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenConfigFromKey(clientID, teamID, keyID, key)
+}
+
+// NewTokenConfigFromReader 从r中读取私钥创建token配置
+func NewTokenConfigFromReader(clientID, teamID, keyID string, r io.Reader) (*TokenConfig, error) {
+	key, err := ReadPrivateKey(r)
 	if err != nil {
 		return nil, err
 	}
+	return NewTokenConfigFromKey(clientID, teamID, keyID, key)
+}
+
+// NewTokenConfigFromKey 使用已解析的私钥创建token配置
+func NewTokenConfigFromKey(clientID, teamID, keyID string, key *ecdsa.PrivateKey) (*TokenConfig, error) {
+	if key == nil {
+		return nil, errors.New("asa: private key is nil")
+	}
+	if key.Curve != elliptic.P256() {
+		return nil, ErrInvalidKeyCurve
+	}
 	gen := &standardJWTGenerator{
 		keyID:          keyID,
 		issuerID:       teamID,
 		clientID:       clientID,
 		privateKey:     key,
 		expireDuration: defaultExpireDuration,
+		refreshBefore:  defaultRefreshBefore,
+		authURL:        defaultAuthURL,
 	}
-	return &TokenConfig{
+	t := &TokenConfig{
 		jwtGenerator: gen,
 		httpReq:      requests.New(),
-	}, nil
+		httpClient:   &http.Client{Timeout: defaultTimeout},
+	}
+	// 换取token与接口请求使用相同的代理与debug配置
+	gen.transport = t.exchangeTransport
+	return t, nil
+}
+
+// SetAuthURL 设置换取access token的地址, 默认为 https://appleid.apple.com/auth/oauth2/token
+func (t *TokenConfig) SetAuthURL(authURL string) error {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return fmt.Errorf("asa: invalid auth url: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("asa: invalid auth url %q", authURL)
+	}
+	g := t.jwtGenerator
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.authURL = authURL
+	return nil
+}
+
+// PublicKeyPEM 生成私钥对应的PEM格式公钥, 用于上传到Apple Search Ads后台
+func (t *TokenConfig) PublicKeyPEM() (string, error) {
+	g := t.jwtGenerator
+	g.mu.Lock()
+	key := g.privateKey
+	g.mu.Unlock()
+	return PublicKeyPEM(key)
+}
+
+// exchangeTransport 换取token使用的http.Client与debug配置
+func (t *TokenConfig) exchangeTransport() (*http.Client, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.httpClient, t.httpReq.Debug
 }
 
 // SetHTTPDebug 设置http请求debug
 func (t *TokenConfig) SetHTTPDebug(flag bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	t.httpReq.Debug = flag
 }
 
 // SetHTTPProxy 设置http请求代理
-func (t *TokenConfig) SetHTTPProxy(proxyUrl string) {
+func (t *TokenConfig) SetHTTPProxy(proxyUrl string) error {
+	transport, err := proxyTransport(proxyUrl)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	t.httpReq.SetProxy(proxyUrl)
+	t.updateHTTPClientLocked(func(httpClient *http.Client) {
+		httpClient.Transport = transport
+	})
+	return nil
+}
+
+// updateHTTPClient 复制当前http.Client修改后替换, 不影响正在使用旧http.Client的请求
+func (t *TokenConfig) updateHTTPClient(fn func(httpClient *http.Client)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.updateHTTPClientLocked(fn)
+}
+
+// updateHTTPClientLocked 同 updateHTTPClient, 调用方需持有锁
+func (t *TokenConfig) updateHTTPClientLocked(fn func(httpClient *http.Client)) {
+	httpClient := *t.httpClient
+	fn(&httpClient)
+	t.httpClient = &httpClient
 }
 
 func (t *TokenConfig) SetOrgID(orgID int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	t.orgID = orgID
 }
 
+// Use 注册access token换取的回调, 可用于日志、监控等
+func (t *TokenConfig) Use(hooks ...TokenHook) {
+	g := t.jwtGenerator
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// 复制后追加, 不影响进行中的换取
+	hs := make([]TokenHook, 0, len(g.hooks)+len(hooks))
+	g.hooks = append(append(hs, g.hooks...), hooks...)
+}
+
+// currentOrgID 获取当前组织ID
+func (t *TokenConfig) currentOrgID() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.orgID
+}
+
+// requestConfig 获取共用此配置的客户端发送接口请求使用的配置
+func (t *TokenConfig) requestConfig() (*requestConfig, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cfg, err := readRequestConfig(t.httpReq)
+	if err != nil {
+		return nil, err
+	}
+	cfg.httpClient = t.httpClient
+	return cfg, nil
+}
+
+// setHTTPTimeout 设置http请求超时时间
+func (t *TokenConfig) setHTTPTimeout(n time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.httpReq.SetTimeout(n)
+	t.updateHTTPClientLocked(func(httpClient *http.Client) {
+		httpClient.Timeout = n
+	})
+}
+
 // GenerateClientSecret 生成client secret https://developer.apple.com/documentation/apple_search_ads/implementing_oauth_for_the_apple_search_ads_api
 func (t *TokenConfig) GenerateClientSecret() (string, error) {
 	return t.jwtGenerator.Token()
 }
 
 func (t *TokenConfig) Client() (*requests.Request, error) {
-	tokenStr, err := t.jwtGenerator.AccessToken()
+	tokenStr, err := t.jwtGenerator.AccessToken(context.Background())
 	if err != nil {
 		return nil, err
 	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	t.httpReq.SetHeader("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
 	if t.orgID > 0 {
 		t.httpReq.SetHeader("X-AP-Context", fmt.Sprintf("orgId=%d", t.orgID))
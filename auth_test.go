@@ -1,8 +1,21 @@
 package asa
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 var (
@@ -31,3 +44,126 @@ func TestNewTokenConfig(t *testing.T) {
 	}
 	fmt.Println(clientSecret)
 }
+
+// newTestPrivateKey 生成测试用的P-256私钥PEM
+func newTestPrivateKey(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+}
+
+// newTestTokenConfig 创建使用本地授权服务的token配置
+func newTestTokenConfig(t *testing.T, handler http.HandlerFunc) *TokenConfig {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	auth, err := NewTokenConfig("SEARCHADS.test", "SEARCHADS.test", "key", newTestPrivateKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := auth.SetAuthURL(srv.URL + "/auth/oauth2/token"); err != nil {
+		t.Fatal(err)
+	}
+	return auth
+}
+
+// go test -v -race -run TestAccessTokenSingleFlight
+func TestAccessTokenSingleFlight(t *testing.T) {
+	t.Parallel()
+
+	var exchanges int32
+	auth := newTestTokenConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&exchanges, 1)
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"access_token":"abc","token_type":"Bearer","expires_in":3600}`))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			auth.SetOrgID(int64(i))
+			tokenStr, err := auth.jwtGenerator.AccessToken(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "abc", tokenStr)
+			_, err = auth.GenerateClientSecret()
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&exchanges))
+}
+
+// go test -v -run TestOAuthExchange
+func TestOAuthExchange(t *testing.T) {
+	t.Parallel()
+
+	auth := newTestTokenConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/auth/oauth2/token", r.URL.Path)
+		assert.Empty(t, r.URL.RawQuery)
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "SEARCHADS.test", r.PostForm.Get("client_id"))
+		assert.Equal(t, "searchadsorg", r.PostForm.Get("scope"))
+		assert.NotEmpty(t, r.PostForm.Get("client_secret"))
+		_, _ = w.Write([]byte(`{"access_token":"abc","token_type":"Bearer","expires_in":3600}`))
+	})
+	token, err := auth.jwtGenerator.AccessToken(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", token)
+}
+
+// go test -v -run TestOAuthExchangeProxy
+func TestOAuthExchangeProxy(t *testing.T) {
+	t.Parallel()
+
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+		assert.Equal(t, "appleid.example", r.URL.Host)
+		_, _ = w.Write([]byte(`{"access_token":"abc","token_type":"Bearer","expires_in":3600}`))
+	}))
+	t.Cleanup(proxy.Close)
+
+	auth, err := NewTokenConfig("SEARCHADS.test", "SEARCHADS.test", "key", newTestPrivateKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, auth.SetAuthURL("http://appleid.example/auth/oauth2/token"))
+	assert.NoError(t, auth.SetHTTPProxy(proxy.URL))
+
+	_, err = auth.jwtGenerator.AccessToken(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&proxied))
+	assert.Error(t, auth.SetAuthURL("not a url"))
+	assert.Error(t, auth.SetHTTPProxy("http://[::1"))
+}
+
+// go test -v -run TestOAuthError
+func TestOAuthError(t *testing.T) {
+	t.Parallel()
+
+	auth := newTestTokenConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"client secret expired"}`))
+	})
+	_, err := auth.jwtGenerator.AccessToken(context.Background())
+
+	var oauthErr *OAuthError
+	if assert.ErrorAs(t, err, &oauthErr) {
+		assert.Equal(t, http.StatusBadRequest, oauthErr.StatusCode)
+		assert.Equal(t, "invalid_client", oauthErr.Code)
+		assert.Equal(t, "client secret expired", oauthErr.Description)
+	}
+	assert.EqualError(t, err, "asa: oauth token exchange: 400 invalid_client: client secret expired")
+	assert.True(t, IsUnauthorized(err))
+}
@@ -1,6 +1,7 @@
 package asa
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -249,19 +250,40 @@ type CampaignResponse struct {
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_all_campaigns
 func (s *CampaignService) GetAllCampaigns(params *GetAllCampaignQuery) (*CampaignListResponse, error) {
+	return s.GetAllCampaignsWithContext(context.Background(), params)
+}
+
+// GetAllCampaignsWithContext is like GetAllCampaigns but uses ctx to cancel the request or bound its deadline.
+func (s *CampaignService) GetAllCampaignsWithContext(ctx context.Context, params *GetAllCampaignQuery) (*CampaignListResponse, error) {
 	url := "campaigns"
 	res := new(CampaignListResponse)
-	err := s.client.get(url, res, params)
+	err := s.client.get(ctx, url, res, params)
 	return res, err
 }
 
+// GetAllCampaignsPager returns a Pager that walks all campaigns page by page via GetAllCampaigns.
+func (s *CampaignService) GetAllCampaignsPager() *Pager[*Campaign] {
+	return NewPager(func(ctx context.Context, offset, limit int) ([]*Campaign, *PageDetail, error) {
+		res, err := s.GetAllCampaignsWithContext(ctx, &GetAllCampaignQuery{Limit: int32(limit), Offset: int32(offset)})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Campaigns, res.Pagination, nil
+	})
+}
+
 // GetCampaign Fetches a specific campaign by campaign identifier
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_a_campaign
 func (s *CampaignService) GetCampaign(campaignID int64) (*CampaignResponse, error) {
+	return s.GetCampaignWithContext(context.Background(), campaignID)
+}
+
+// GetCampaignWithContext is like GetCampaign but uses ctx to cancel the request or bound its deadline.
+func (s *CampaignService) GetCampaignWithContext(ctx context.Context, campaignID int64) (*CampaignResponse, error) {
 	url := fmt.Sprintf("campaigns/%d", campaignID)
 	res := new(CampaignResponse)
-	err := s.client.get(url, res)
+	err := s.client.get(ctx, url, res)
 	return res, err
 }
 
@@ -269,9 +291,39 @@ func (s *CampaignService) GetCampaign(campaignID int64) (*CampaignResponse, erro
 //
 // https://developer.apple.com/documentation/apple_search_ads/find_campaigns
 func (s *CampaignService) FindCampaigns(selector *Selector) (*CampaignListResponse, error) {
+	return s.FindCampaignsWithContext(context.Background(), selector)
+}
+
+// FindCampaignsWithContext is like FindCampaigns but uses ctx to cancel the request or bound its deadline.
+func (s *CampaignService) FindCampaignsWithContext(ctx context.Context, selector *Selector) (*CampaignListResponse, error) {
 	url := "campaigns/find"
 	res := new(CampaignListResponse)
-	err := s.client.post(url, res, selector)
+	err := s.client.find(ctx, url, res, selector)
+
+	return res, err
+}
+
+// FindCampaignsPager returns a Pager that walks all campaigns matching selector page by page via FindCampaigns.
+func (s *CampaignService) FindCampaignsPager(selector *Selector) *Pager[*Campaign] {
+	return newSelectorPager(selector, func(ctx context.Context, offset, limit int) ([]*Campaign, *PageDetail, error) {
+		res, err := s.FindCampaignsWithContext(ctx, pageSelector(selector, offset, limit))
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Campaigns, res.Pagination, nil
+	})
+}
+
+// FindCampaignsProjected is like FindCampaigns but reports which fields each record contains.
+func (s *CampaignService) FindCampaignsProjected(selector *Selector) (*ProjectedListResponse[Campaign], error) {
+	return s.FindCampaignsProjectedWithContext(context.Background(), selector)
+}
+
+// FindCampaignsProjectedWithContext is like FindCampaignsProjected but uses ctx to cancel the request or bound its deadline.
+func (s *CampaignService) FindCampaignsProjectedWithContext(ctx context.Context, selector *Selector) (*ProjectedListResponse[Campaign], error) {
+	url := "campaigns/find"
+	res := new(ProjectedListResponse[Campaign])
+	err := s.client.find(ctx, url, res, selector)
 
 	return res, err
 }
@@ -280,9 +332,14 @@ func (s *CampaignService) FindCampaigns(selector *Selector) (*CampaignListRespon
 //
 // https://developer.apple.com/documentation/apple_search_ads/create_a_campaign
 func (s *CampaignService) CreateCampaign(campaign *Campaign) (*CampaignResponse, error) {
+	return s.CreateCampaignWithContext(context.Background(), campaign)
+}
+
+// CreateCampaignWithContext is like CreateCampaign but uses ctx to cancel the request or bound its deadline.
+func (s *CampaignService) CreateCampaignWithContext(ctx context.Context, campaign *Campaign) (*CampaignResponse, error) {
 	url := "campaigns"
 	res := new(CampaignResponse)
-	err := s.client.post(url, res, campaign)
+	err := s.client.post(ctx, url, res, campaign)
 
 	return res, err
 }
@@ -291,9 +348,14 @@ func (s *CampaignService) CreateCampaign(campaign *Campaign) (*CampaignResponse,
 //
 // https://developer.apple.com/documentation/apple_search_ads/delete_a_campaign
 func (s *CampaignService) DeleteCampaign(campaignID int64) (*BaseResponse, error) {
+	return s.DeleteCampaignWithContext(context.Background(), campaignID)
+}
+
+// DeleteCampaignWithContext is like DeleteCampaign but uses ctx to cancel the request or bound its deadline.
+func (s *CampaignService) DeleteCampaignWithContext(ctx context.Context, campaignID int64) (*BaseResponse, error) {
 	url := fmt.Sprintf("campaigns/%d", campaignID)
 	res := new(BaseResponse)
-	err := s.client.delete(url, res)
+	err := s.client.delete(ctx, url, res)
 
 	return res, err
 }
@@ -302,9 +364,14 @@ func (s *CampaignService) DeleteCampaign(campaignID int64) (*BaseResponse, error
 //
 // https://developer.apple.com/documentation/apple_search_ads/update_a_campaign
 func (s *CampaignService) UpdateCampaign(campaignID int64, req *UpdateCampaignRequest) (*CampaignResponse, error) {
+	return s.UpdateCampaignWithContext(context.Background(), campaignID, req)
+}
+
+// UpdateCampaignWithContext is like UpdateCampaign but uses ctx to cancel the request or bound its deadline.
+func (s *CampaignService) UpdateCampaignWithContext(ctx context.Context, campaignID int64, req *UpdateCampaignRequest) (*CampaignResponse, error) {
 	url := fmt.Sprintf("campaigns/%d", campaignID)
 	res := new(CampaignResponse)
-	err := s.client.put(url, res, req)
+	err := s.client.put(ctx, url, res, req)
 
 	return res, err
 }
@@ -2,8 +2,11 @@ package asa
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 // go test -v -run TestGetAllCampaigns
@@ -88,3 +91,23 @@ func TestCreateCampaign(t *testing.T) {
 	}
 	fmt.Println(res.Campaign)
 }
+
+// go test -v -run TestGetAllCampaignsDefaultLimit
+func TestGetAllCampaignsDefaultLimit(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	params := &GetAllCampaignQuery{Offset: 20}
+	_, err := c.Campaigns.GetAllCampaigns(params)
+	assert.NoError(t, err)
+	_, err = c.Campaigns.GetAllCampaigns(&GetAllCampaignQuery{Limit: 5})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"limit=10&offset=20", "limit=5"}, queries)
+	// 默认值不写回调用方的结构体
+	assert.Equal(t, int32(0), params.Limit)
+}
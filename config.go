@@ -0,0 +1,390 @@
+package asa
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 环境变量名
+const (
+	EnvConfigFile     = "ASA_CONFIG_FILE"
+	EnvProfile        = "ASA_PROFILE"
+	EnvClientID       = "ASA_CLIENT_ID"
+	EnvTeamID         = "ASA_TEAM_ID"
+	EnvKeyID          = "ASA_KEY_ID"
+	EnvPrivateKey     = "ASA_PRIVATE_KEY"
+	EnvPrivateKeyFile = "ASA_PRIVATE_KEY_FILE"
+	EnvAccessToken    = "ASA_ACCESS_TOKEN"
+	EnvOrgID          = "ASA_ORG_ID"
+	EnvProxy          = "ASA_PROXY"
+	EnvTimeout        = "ASA_TIMEOUT"
+	EnvBaseURL        = "ASA_BASE_URL"
+	EnvAuthURL        = "ASA_AUTH_URL"
+)
+
+// DefaultProfile 未指定profile时使用的profile名
+const DefaultProfile = "default"
+
+// Duration 可以从 "30s"、"1m" 这类字符串解析的时间间隔
+type Duration time.Duration
+
+// UnmarshalText 解析 time.ParseDuration 格式的字符串
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalText 输出 time.Duration 的字符串格式
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// Config 客户端配置, 使用client secret认证时需要 ClientID、TeamID、KeyID 以及 PrivateKey 或 PrivateKeyFile,
+// 也可以只提供 AccessToken
+type Config struct {
+	ClientID string `json:"client_id,omitempty" yaml:"client_id,omitempty"`
+	TeamID   string `json:"team_id,omitempty" yaml:"team_id,omitempty"`
+	KeyID    string `json:"key_id,omitempty" yaml:"key_id,omitempty"`
+	// PrivateKey 私钥内容, 支持的格式同 ParsePrivateKey
+	PrivateKey string `json:"private_key,omitempty" yaml:"private_key,omitempty"`
+	// PrivateKeyFile 私钥文件路径
+	PrivateKeyFile string `json:"private_key_file,omitempty" yaml:"private_key_file,omitempty"`
+	// AccessToken 固定的access token, 设置后不再使用client secret换取token
+	AccessToken string   `json:"access_token,omitempty" yaml:"access_token,omitempty"`
+	OrgID       int64    `json:"org_id,omitempty" yaml:"org_id,omitempty"`
+	Proxy       string   `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	Timeout     Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	BaseURL     string   `json:"base_url,omitempty" yaml:"base_url,omitempty"`
+	AuthURL     string   `json:"auth_url,omitempty" yaml:"auth_url,omitempty"`
+}
+
+// ConfigError 配置校验错误, 列出所有缺失与无效的字段
+type ConfigError struct {
+	// Missing 缺失的字段
+	Missing []string
+	// Invalid 无效的字段及原因
+	Invalid []string
+}
+
+func (e *ConfigError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Invalid) > 0 {
+		parts = append(parts, "invalid "+strings.Join(e.Invalid, ", "))
+	}
+	return "asa: config: " + strings.Join(parts, "; ")
+}
+
+func (e *ConfigError) empty() bool {
+	return len(e.Missing) == 0 && len(e.Invalid) == 0
+}
+
+// Validate 校验配置, 返回的 *ConfigError 包含所有问题
+func (c *Config) Validate() error {
+	cfgErr := &ConfigError{}
+	c.validate(cfgErr)
+	if cfgErr.empty() {
+		return nil
+	}
+	return cfgErr
+}
+
+func (c *Config) validate(cfgErr *ConfigError) {
+	if c.AccessToken == "" {
+		required := []struct{ name, env, value string }{
+			{"client_id", EnvClientID, c.ClientID},
+			{"team_id", EnvTeamID, c.TeamID},
+			{"key_id", EnvKeyID, c.KeyID},
+		}
+		for _, f := range required {
+			if f.value == "" {
+				cfgErr.Missing = append(cfgErr.Missing, fmt.Sprintf("%s (%s)", f.name, f.env))
+			}
+		}
+		if c.PrivateKey == "" && c.PrivateKeyFile == "" {
+			cfgErr.Missing = append(cfgErr.Missing, fmt.Sprintf("private_key or private_key_file (%s or %s)", EnvPrivateKey, EnvPrivateKeyFile))
+		}
+	}
+	if c.OrgID < 0 {
+		cfgErr.Invalid = append(cfgErr.Invalid, fmt.Sprintf("org_id: %d", c.OrgID))
+	}
+	if c.Timeout < 0 {
+		cfgErr.Invalid = append(cfgErr.Invalid, fmt.Sprintf("timeout: %s", time.Duration(c.Timeout)))
+	}
+}
+
+// merge 用other中的非零字段覆盖c
+func (c *Config) merge(other *Config) {
+	if other == nil {
+		return
+	}
+	set := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+	set(&c.ClientID, other.ClientID)
+	set(&c.TeamID, other.TeamID)
+	set(&c.KeyID, other.KeyID)
+	set(&c.PrivateKey, other.PrivateKey)
+	set(&c.PrivateKeyFile, other.PrivateKeyFile)
+	set(&c.AccessToken, other.AccessToken)
+	set(&c.Proxy, other.Proxy)
+	set(&c.BaseURL, other.BaseURL)
+	set(&c.AuthURL, other.AuthURL)
+	if other.OrgID != 0 {
+		c.OrgID = other.OrgID
+	}
+	if other.Timeout != 0 {
+		c.Timeout = other.Timeout
+	}
+}
+
+// loadOptions 配置加载选项
+type loadOptions struct {
+	file      string
+	profile   string
+	lookupEnv func(key string) (string, bool)
+	overrides []*Config
+}
+
+// LoadOption 配置加载选项
+type LoadOption func(o *loadOptions)
+
+// WithConfigFile 指定profile文件, 默认读取环境变量 ASA_CONFIG_FILE, 都未设置时不读取文件
+func WithConfigFile(path string) LoadOption {
+	return func(o *loadOptions) {
+		o.file = path
+	}
+}
+
+// WithProfile 指定profile名, 默认读取环境变量 ASA_PROFILE, 都未设置时为 default
+func WithProfile(name string) LoadOption {
+	return func(o *loadOptions) {
+		o.profile = name
+	}
+}
+
+// WithLookupEnv 指定环境变量的读取方式, 传nil时不读取环境变量
+func WithLookupEnv(lookupEnv func(key string) (string, bool)) LoadOption {
+	return func(o *loadOptions) {
+		o.lookupEnv = lookupEnv
+	}
+}
+
+// WithOverrides 使用cfg中的非零字段覆盖文件与环境变量中的配置
+func WithOverrides(cfg Config) LoadOption {
+	return func(o *loadOptions) {
+		o.overrides = append(o.overrides, &cfg)
+	}
+}
+
+// LoadConfig 加载配置, 优先级从低到高依次为: profile文件、环境变量、WithOverrides
+//
+// profile文件为JSON或YAML格式, 顶层为profile名到配置的映射, 例如:
+//
+//	default:
+//	  client_id: SEARCHADS.xxx
+//	  team_id: SEARCHADS.xxx
+//	  key_id: xxx
+//	  private_key_file: ~/.asa/private-key.pem
+//	  org_id: 123
+//	staging:
+//	  access_token: xxx
+//
+// 所有缺失与无效的字段会通过 *ConfigError 一次性返回。
+func LoadConfig(opts ...LoadOption) (*Config, error) {
+	o := &loadOptions{lookupEnv: os.LookupEnv}
+	for _, opt := range opts {
+		opt(o)
+	}
+	lookupEnv := o.lookupEnv
+	if lookupEnv == nil {
+		lookupEnv = func(string) (string, bool) { return "", false }
+	}
+	if o.file == "" {
+		o.file, _ = lookupEnv(EnvConfigFile)
+	}
+	if o.profile == "" {
+		o.profile, _ = lookupEnv(EnvProfile)
+	}
+	if o.profile == "" {
+		o.profile = DefaultProfile
+	}
+
+	cfg := &Config{}
+	if o.file != "" {
+		profile, err := loadProfile(o.file, o.profile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.merge(profile)
+	}
+
+	cfgErr := &ConfigError{}
+	cfg.merge(configFromEnv(lookupEnv, cfgErr))
+	for _, override := range o.overrides {
+		cfg.merge(override)
+	}
+	cfg.validate(cfgErr)
+	if !cfgErr.empty() {
+		return nil, cfgErr
+	}
+	return cfg, nil
+}
+
+// loadProfile 从profile文件中读取指定profile
+func loadProfile(path, name string) (*Config, error) {
+	content, err := os.ReadFile(expandHome(path))
+	if err != nil {
+		return nil, fmt.Errorf("asa: read config file: %w", err)
+	}
+	// YAML兼容JSON, 两种格式使用同一个解析器
+	var profiles map[string]*Config
+	if err := yaml.Unmarshal(content, &profiles); err != nil {
+		return nil, fmt.Errorf("asa: parse config file %s: %w", path, err)
+	}
+	profile, ok := profiles[name]
+	if !ok || profile == nil {
+		return nil, fmt.Errorf("asa: profile %q not found in %s", name, path)
+	}
+	return profile, nil
+}
+
+// configFromEnv 从环境变量读取配置, 无法解析的值记录到cfgErr
+func configFromEnv(lookupEnv func(string) (string, bool), cfgErr *ConfigError) *Config {
+	get := func(key string) string {
+		v, _ := lookupEnv(key)
+		return strings.TrimSpace(v)
+	}
+	cfg := &Config{
+		ClientID:       get(EnvClientID),
+		TeamID:         get(EnvTeamID),
+		KeyID:          get(EnvKeyID),
+		PrivateKey:     get(EnvPrivateKey),
+		PrivateKeyFile: get(EnvPrivateKeyFile),
+		AccessToken:    get(EnvAccessToken),
+		Proxy:          get(EnvProxy),
+		BaseURL:        get(EnvBaseURL),
+		AuthURL:        get(EnvAuthURL),
+	}
+	if v := get(EnvOrgID); v != "" {
+		orgID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			cfgErr.Invalid = append(cfgErr.Invalid, fmt.Sprintf("%s: %q is not an integer", EnvOrgID, v))
+		}
+		cfg.OrgID = orgID
+	}
+	if v := get(EnvTimeout); v != "" {
+		if err := cfg.Timeout.UnmarshalText([]byte(v)); err != nil {
+			cfgErr.Invalid = append(cfgErr.Invalid, fmt.Sprintf("%s: %q is not a duration", EnvTimeout, v))
+		}
+	}
+	return cfg
+}
+
+// expandHome 展开路径开头的 ~
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return home + path[1:]
+}
+
+// TokenConfig 根据配置创建 TokenConfig, 使用 AccessToken 时返回nil
+func (c *Config) TokenConfig() (*TokenConfig, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.AccessToken != "" {
+		return nil, nil
+	}
+	var (
+		auth *TokenConfig
+		err  error
+	)
+	if c.PrivateKey != "" {
+		auth, err = NewTokenConfig(c.ClientID, c.TeamID, c.KeyID, c.PrivateKey)
+	} else {
+		auth, err = NewTokenConfigFromFile(c.ClientID, c.TeamID, c.KeyID, expandHome(c.PrivateKeyFile))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.AuthURL != "" {
+		if err := auth.SetAuthURL(c.AuthURL); err != nil {
+			return nil, err
+		}
+	}
+	if c.Proxy != "" {
+		if err := auth.SetHTTPProxy(c.Proxy); err != nil {
+			return nil, err
+		}
+	}
+	auth.SetOrgID(c.OrgID)
+	return auth, nil
+}
+
+// Options 将配置转换为 New 使用的配置项
+func (c *Config) Options() ([]Option, error) {
+	auth, err := c.TokenConfig()
+	if err != nil {
+		return nil, err
+	}
+	var opts []Option
+	if auth != nil {
+		opts = append(opts, WithTokenConfig(auth))
+	} else {
+		opts = append(opts, WithAccessToken(c.AccessToken))
+	}
+	if c.OrgID > 0 {
+		opts = append(opts, WithDefaultOrgID(c.OrgID))
+	}
+	if c.Proxy != "" {
+		transport, err := proxyTransport(c.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("asa: invalid proxy: %w", err)
+		}
+		opts = append(opts, WithTransport(transport))
+	}
+	if c.Timeout > 0 {
+		opts = append(opts, WithTimeout(time.Duration(c.Timeout)))
+	}
+	if c.BaseURL != "" {
+		opts = append(opts, WithBaseURL(c.BaseURL))
+	}
+	return opts, nil
+}
+
+// NewClient 根据配置创建客户端, opts 在配置之后生效
+func (c *Config) NewClient(opts ...Option) (*Client, error) {
+	cfgOpts, err := c.Options()
+	if err != nil {
+		return nil, err
+	}
+	return New(append(cfgOpts, opts...)...)
+}
+
+// LoadClient 加载配置并创建客户端, 等价于 LoadConfig 后调用 Config.NewClient
+func LoadClient(opts ...LoadOption) (*Client, error) {
+	cfg, err := LoadConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.NewClient()
+}
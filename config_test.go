@@ -0,0 +1,127 @@
+package asa
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mapEnv 用map模拟环境变量
+func mapEnv(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+// go test -v -run TestLoadConfig
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key.pem")
+	assert.NoError(t, os.WriteFile(keyFile, []byte(newTestPrivateKey(t)), 0o600))
+	profiles := `
+default:
+  client_id: SEARCHADS.default
+  team_id: SEARCHADS.team
+  key_id: default-key
+  private_key_file: ` + keyFile + `
+  org_id: 1
+  timeout: 10s
+prod:
+  client_id: SEARCHADS.prod
+  team_id: SEARCHADS.team
+  key_id: prod-key
+  private_key_file: ` + keyFile + `
+  org_id: 2
+`
+	file := filepath.Join(dir, "asa.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(profiles), 0o600))
+
+	// 文件 < 环境变量 < overrides
+	cfg, err := LoadConfig(
+		WithConfigFile(file),
+		WithLookupEnv(mapEnv(map[string]string{EnvProfile: "prod", EnvOrgID: "3", EnvKeyID: "env-key"})),
+		WithOverrides(Config{OrgID: 4}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "SEARCHADS.prod", cfg.ClientID)
+	assert.Equal(t, "env-key", cfg.KeyID)
+	assert.Equal(t, int64(4), cfg.OrgID)
+
+	cfg, err = LoadConfig(WithConfigFile(file), WithLookupEnv(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "SEARCHADS.default", cfg.ClientID)
+	assert.Equal(t, Duration(10*time.Second), cfg.Timeout)
+
+	jsonFile := filepath.Join(dir, "asa.json")
+	assert.NoError(t, os.WriteFile(jsonFile, []byte(`{"ci":{"access_token":"token","org_id":5,"timeout":"1m"}}`), 0o600))
+	cfg, err = LoadConfig(WithConfigFile(jsonFile), WithProfile("ci"), WithLookupEnv(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "token", cfg.AccessToken)
+	assert.Equal(t, Duration(time.Minute), cfg.Timeout)
+
+	_, err = LoadConfig(WithConfigFile(file), WithProfile("missing"), WithLookupEnv(nil))
+	assert.EqualError(t, err, `asa: profile "missing" not found in `+file)
+}
+
+// go test -v -run TestLoadConfigValidation
+func TestLoadConfigValidation(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadConfig(WithLookupEnv(mapEnv(map[string]string{
+		EnvClientID: "SEARCHADS.test",
+		EnvOrgID:    "abc",
+		EnvTimeout:  "soon",
+	})))
+	var cfgErr *ConfigError
+	if assert.True(t, errors.As(err, &cfgErr)) {
+		assert.Equal(t, []string{
+			"team_id (ASA_TEAM_ID)",
+			"key_id (ASA_KEY_ID)",
+			"private_key or private_key_file (ASA_PRIVATE_KEY or ASA_PRIVATE_KEY_FILE)",
+		}, cfgErr.Missing)
+		assert.Len(t, cfgErr.Invalid, 2)
+	}
+	assert.Contains(t, err.Error(), "missing team_id (ASA_TEAM_ID), key_id")
+}
+
+// go test -v -run TestConfigNewClient
+func TestConfigNewClient(t *testing.T) {
+	t.Parallel()
+
+	var got *http.Request
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		got = req
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Header: http.Header{}, Request: req}, nil
+	})
+
+	cfg, err := LoadConfig(WithLookupEnv(mapEnv(map[string]string{
+		EnvAccessToken: "token",
+		EnvOrgID:       "42",
+		EnvBaseURL:     "http://asa.local/api/v5/",
+		EnvTimeout:     "5s",
+	})))
+	assert.NoError(t, err)
+	c, err := cfg.NewClient(WithTransport(transport))
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, c.httpClient.Timeout)
+
+	_, err = c.Campaigns.DeleteCampaign(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://asa.local/api/v5/campaigns/1", got.URL.String())
+	assert.Equal(t, "Bearer token", got.Header.Get("Authorization"))
+	assert.Equal(t, "orgId=42", got.Header.Get("X-AP-Context"))
+
+	key := newTestPrivateKey(t)
+	auth, err := (&Config{ClientID: "client", TeamID: "team", KeyID: "key", PrivateKey: key, OrgID: 7, AuthURL: "http://auth.local/token"}).TokenConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), auth.currentOrgID())
+	assert.Equal(t, "http://auth.local/token", auth.jwtGenerator.authURL)
+}
@@ -0,0 +1,177 @@
+package asa
+
+import (
+	"context"
+	"fmt"
+)
+
+// CreativeService handles communication with creative and custom product page methods of the Apple Search Ads API
+//
+// https://developer.apple.com/documentation/apple_search_ads/creatives
+type CreativeService service
+
+// GetProductPages fetches the custom product pages of an app
+//
+// https://developer.apple.com/documentation/apple_search_ads/get_product_pages
+func (s *CreativeService) GetProductPages(adamID int64, params *GetProductPagesQuery) (*ProductPageDetailListResponse, error) {
+	return s.GetProductPagesWithContext(context.Background(), adamID, params)
+}
+
+// GetProductPagesWithContext is like GetProductPages but uses ctx to cancel the request or bound its deadline.
+func (s *CreativeService) GetProductPagesWithContext(ctx context.Context, adamID int64, params *GetProductPagesQuery) (*ProductPageDetailListResponse, error) {
+	url := fmt.Sprintf("apps/%d/product-pages", adamID)
+	res := new(ProductPageDetailListResponse)
+	err := s.client.get(ctx, url, res, params)
+
+	return res, err
+}
+
+// GetProductPage fetches a custom product page of an app by identifier
+//
+// https://developer.apple.com/documentation/apple_search_ads/get_product_pages_by_identifier
+func (s *CreativeService) GetProductPage(adamID int64, productPageID string) (*ProductPageDetailResponse, error) {
+	return s.GetProductPageWithContext(context.Background(), adamID, productPageID)
+}
+
+// GetProductPageWithContext is like GetProductPage but uses ctx to cancel the request or bound its deadline.
+func (s *CreativeService) GetProductPageWithContext(ctx context.Context, adamID int64, productPageID string) (*ProductPageDetailResponse, error) {
+	url := fmt.Sprintf("apps/%d/product-pages/%s", adamID, productPageID)
+	res := new(ProductPageDetailResponse)
+	err := s.client.get(ctx, url, res)
+
+	return res, err
+}
+
+// GetProductPageLocales fetches the localized metadata and assets of a custom product page
+//
+// https://developer.apple.com/documentation/apple_search_ads/get_product_page_locales
+func (s *CreativeService) GetProductPageLocales(adamID int64, productPageID string, params *GetProductPageLocalesQuery) (*ProductPageLocaleDetailListResponse, error) {
+	return s.GetProductPageLocalesWithContext(context.Background(), adamID, productPageID, params)
+}
+
+// GetProductPageLocalesWithContext is like GetProductPageLocales but uses ctx to cancel the request or bound its deadline.
+func (s *CreativeService) GetProductPageLocalesWithContext(ctx context.Context, adamID int64, productPageID string, params *GetProductPageLocalesQuery) (*ProductPageLocaleDetailListResponse, error) {
+	url := fmt.Sprintf("apps/%d/product-pages/%s/locale-details", adamID, productPageID)
+	res := new(ProductPageLocaleDetailListResponse)
+	err := s.client.get(ctx, url, res, params)
+
+	return res, err
+}
+
+// GetSupportedCountriesOrRegions fetches the countries or regions that support custom product pages
+//
+// https://developer.apple.com/documentation/apple_search_ads/get_supported_countries_or_regions
+func (s *CreativeService) GetSupportedCountriesOrRegions(params *GetSupportedCountriesOrRegionsQuery) (*CountryOrRegionListResponse, error) {
+	return s.GetSupportedCountriesOrRegionsWithContext(context.Background(), params)
+}
+
+// GetSupportedCountriesOrRegionsWithContext is like GetSupportedCountriesOrRegions but uses ctx to cancel the request or bound its deadline.
+func (s *CreativeService) GetSupportedCountriesOrRegionsWithContext(ctx context.Context, params *GetSupportedCountriesOrRegionsQuery) (*CountryOrRegionListResponse, error) {
+	url := "countries-or-regions"
+	res := new(CountryOrRegionListResponse)
+	err := s.client.get(ctx, url, res, params)
+
+	return res, err
+}
+
+// GetAppPreviewDeviceSizes fetches the app preview device sizes used by product page assets
+//
+// https://developer.apple.com/documentation/apple_search_ads/get_app_preview_device_sizes
+func (s *CreativeService) GetAppPreviewDeviceSizes() (*AppPreviewDevicesResponse, error) {
+	return s.GetAppPreviewDeviceSizesWithContext(context.Background())
+}
+
+// GetAppPreviewDeviceSizesWithContext is like GetAppPreviewDeviceSizes but uses ctx to cancel the request or bound its deadline.
+func (s *CreativeService) GetAppPreviewDeviceSizesWithContext(ctx context.Context) (*AppPreviewDevicesResponse, error) {
+	url := "creativeappmappings/devices"
+	res := new(AppPreviewDevicesResponse)
+	err := s.client.get(ctx, url, res)
+
+	return res, err
+}
+
+// CreateCreative creates a creative from a custom product page that ads can reference
+//
+// https://developer.apple.com/documentation/apple_search_ads/create_a_creative
+func (s *CreativeService) CreateCreative(creative *CreativeCreate) (*CreativeResponse, error) {
+	return s.CreateCreativeWithContext(context.Background(), creative)
+}
+
+// CreateCreativeWithContext is like CreateCreative but uses ctx to cancel the request or bound its deadline.
+func (s *CreativeService) CreateCreativeWithContext(ctx context.Context, creative *CreativeCreate) (*CreativeResponse, error) {
+	url := "creatives"
+	res := new(CreativeResponse)
+	err := s.client.post(ctx, url, res, creative)
+
+	return res, err
+}
+
+// GetCreative fetches a creative by identifier
+//
+// https://developer.apple.com/documentation/apple_search_ads/get_a_creative
+func (s *CreativeService) GetCreative(creativeID int64, params *GetCreativeQuery) (*CreativeResponse, error) {
+	return s.GetCreativeWithContext(context.Background(), creativeID, params)
+}
+
+// GetCreativeWithContext is like GetCreative but uses ctx to cancel the request or bound its deadline.
+func (s *CreativeService) GetCreativeWithContext(ctx context.Context, creativeID int64, params *GetCreativeQuery) (*CreativeResponse, error) {
+	url := fmt.Sprintf("creatives/%d", creativeID)
+	res := new(CreativeResponse)
+	err := s.client.get(ctx, url, res, params)
+
+	return res, err
+}
+
+// GetAllCreatives fetches all creatives of the organization
+//
+// https://developer.apple.com/documentation/apple_search_ads/get_all_creatives
+func (s *CreativeService) GetAllCreatives(params *GetAllCreativesQuery) (*CreativeListResponse, error) {
+	return s.GetAllCreativesWithContext(context.Background(), params)
+}
+
+// GetAllCreativesWithContext is like GetAllCreatives but uses ctx to cancel the request or bound its deadline.
+func (s *CreativeService) GetAllCreativesWithContext(ctx context.Context, params *GetAllCreativesQuery) (*CreativeListResponse, error) {
+	url := "creatives"
+	res := new(CreativeListResponse)
+	err := s.client.get(ctx, url, res, params)
+
+	return res, err
+}
+
+// GetAllCreativesPager returns a Pager that walks all creatives page by page via GetAllCreatives.
+func (s *CreativeService) GetAllCreativesPager() *Pager[*Creative] {
+	return NewPager(func(ctx context.Context, offset, limit int) ([]*Creative, *PageDetail, error) {
+		res, err := s.GetAllCreativesWithContext(ctx, &GetAllCreativesQuery{Limit: int32(limit), Offset: int32(offset)})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Creatives, res.Pagination, nil
+	})
+}
+
+// FindCreatives fetches creatives with selector operators
+//
+// https://developer.apple.com/documentation/apple_search_ads/find_creatives
+func (s *CreativeService) FindCreatives(selector *Selector) (*CreativeListResponse, error) {
+	return s.FindCreativesWithContext(context.Background(), selector)
+}
+
+// FindCreativesWithContext is like FindCreatives but uses ctx to cancel the request or bound its deadline.
+func (s *CreativeService) FindCreativesWithContext(ctx context.Context, selector *Selector) (*CreativeListResponse, error) {
+	url := "creatives/find"
+	res := new(CreativeListResponse)
+	err := s.client.find(ctx, url, res, selector)
+
+	return res, err
+}
+
+// FindCreativesPager returns a Pager that walks all creatives matching selector page by page via FindCreatives.
+func (s *CreativeService) FindCreativesPager(selector *Selector) *Pager[*Creative] {
+	return newSelectorPager(selector, func(ctx context.Context, offset, limit int) ([]*Creative, *PageDetail, error) {
+		res, err := s.FindCreativesWithContext(ctx, pageSelector(selector, offset, limit))
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Creatives, res.Pagination, nil
+	})
+}
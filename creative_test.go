@@ -0,0 +1,107 @@
+package asa
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -run TestCreativeService
+func TestCreativeService(t *testing.T) {
+	t.Parallel()
+
+	const creative = `{"id":9,"adamId":123,"name":"summer","productPageId":"pp-1","state":"VALID","type":"CUSTOM_PRODUCT_PAGE"}`
+	var requests []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+		switch r.URL.Path {
+		case "/apps/123/product-pages":
+			_, _ = w.Write([]byte(`{"data":[{"id":"pp-1","adamId":123,"name":"Summer","state":"VISIBLE","deepLink":"app://summer"}]}`))
+		case "/apps/123/product-pages/pp-1":
+			_, _ = w.Write([]byte(`{"data":{"id":"pp-1","adamId":123,"name":"Summer","state":"HIDDEN"}}`))
+		case "/apps/123/product-pages/pp-1/locale-details":
+			_, _ = w.Write([]byte(`{"data":[{"productPageId":"pp-1","languageCode":"en-US","appPreviewDeviceWithAssets":` +
+				`{"iphone_6_5":{"appPreviewDevice":"iphone_6_5","screenshots":[{"assetGenId":"a1","assetType":"SCREENSHOT","sortPosition":1}]}}}]}`))
+		case "/countries-or-regions":
+			_, _ = w.Write([]byte(`{"data":[{"countryOrRegion":"US"}]}`))
+		case "/creativeappmappings/devices":
+			_, _ = w.Write([]byte(`{"data":{"iphone_6_5":"iPhone 6.5\""}}`))
+		case "/creatives", "/creatives/find":
+			if r.Method == http.MethodPost && r.URL.Path == "/creatives" {
+				_, _ = w.Write([]byte(`{"data":` + creative + `}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[` + creative + `],"pagination":{"totalResults":1,"startIndex":0,"itemsPerPage":1}}`))
+		case "/creatives/9":
+			_, _ = w.Write([]byte(`{"data":` + creative + `}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	pages, err := c.Creatives.GetProductPages(123, &GetProductPagesQuery{States: "VISIBLE,HIDDEN"})
+	assert.NoError(t, err)
+	assert.Equal(t, ProductPageStateVisible, pages.ProductPages[0].State)
+	page, err := c.Creatives.GetProductPage(123, "pp-1")
+	assert.NoError(t, err)
+	assert.Equal(t, ProductPageStateHidden, page.ProductPage.State)
+	locales, err := c.Creatives.GetProductPageLocales(123, "pp-1", &GetProductPageLocalesQuery{Expand: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "a1", locales.Locales[0].AppPreviewDeviceWithAssets["iphone_6_5"].Screenshots[0].AssetGenID)
+	countries, err := c.Creatives.GetSupportedCountriesOrRegions(&GetSupportedCountriesOrRegionsQuery{CountriesOrRegions: "US,GB"})
+	assert.NoError(t, err)
+	assert.Equal(t, "US", countries.CountriesOrRegions[0].CountryOrRegion)
+	devices, err := c.Creatives.GetAppPreviewDeviceSizes()
+	assert.NoError(t, err)
+	assert.Equal(t, `iPhone 6.5"`, devices.Devices["iphone_6_5"])
+
+	created, err := c.Creatives.CreateCreative(&CreativeCreate{
+		AdamID: 123, Name: "summer", ProductPageID: "pp-1", Type: AdCreativeTypeCustomProductPage,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, CreativeStateValid, created.Creative.State)
+	_, err = c.Creatives.GetCreative(9, &GetCreativeQuery{IncludeDeletedCreativeSetAssets: true})
+	assert.NoError(t, err)
+	all, err := c.Creatives.GetAllCreativesPager().All(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+	found, err := c.Creatives.FindCreatives(NewCreativeSelector().Where("productPageId").Equals("pp-1").MustBuild())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), found.Creatives[0].ID)
+
+	assert.Equal(t, []string{
+		"GET /apps/123/product-pages?states=VISIBLE%2CHIDDEN ",
+		"GET /apps/123/product-pages/pp-1 ",
+		"GET /apps/123/product-pages/pp-1/locale-details?expand=true ",
+		"GET /countries-or-regions?countriesOrRegions=US%2CGB ",
+		"GET /creativeappmappings/devices ",
+		`POST /creatives {"adamId":123,"name":"summer","productPageId":"pp-1","type":"CUSTOM_PRODUCT_PAGE"}`,
+		"GET /creatives/9?includeDeletedCreativeSetAssets=true ",
+		"GET /creatives?limit=1000 ",
+		`POST /creatives/find {"conditions":[{"field":"productPageId","operator":"EQUALS","values":["pp-1"]}]}`,
+	}, requests)
+
+	// 可选的查询参数传nil时不拼接查询参数
+	requests = nil
+	_, err = c.Creatives.GetProductPages(123, nil)
+	assert.NoError(t, err)
+	_, err = c.Creatives.GetProductPageLocales(123, "pp-1", nil)
+	assert.NoError(t, err)
+	_, err = c.Creatives.GetSupportedCountriesOrRegions(nil)
+	assert.NoError(t, err)
+	_, err = c.Creatives.GetCreative(9, nil)
+	assert.NoError(t, err)
+	_, err = c.Creatives.GetAllCreatives(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /apps/123/product-pages ",
+		"GET /apps/123/product-pages/pp-1/locale-details ",
+		"GET /countries-or-regions ",
+		"GET /creatives/9 ",
+		"GET /creatives ",
+	}, requests)
+}
@@ -0,0 +1,175 @@
+package asa
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError 接口返回的错误, 可以通过 errors.As 获取
+//
+// https://developer.apple.com/documentation/apple_search_ads/apierrorresponse
+type APIError struct {
+	// StatusCode http状态码
+	StatusCode int
+	// Method 请求方法
+	Method string
+	// URL 请求地址
+	URL string
+	// Errors 接口返回的错误详情
+	Errors []ErrorResponseItem
+	// Message 无错误详情时的错误信息
+	Message string
+	// Body 原始响应内容
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "asa: %s %s: %d", e.Method, e.URL, e.StatusCode)
+	if len(e.Errors) == 0 {
+		if e.Message != "" {
+			b.WriteString(" " + e.Message)
+		}
+		return b.String()
+	}
+	for i, item := range e.Errors {
+		if i > 0 {
+			b.WriteString(";")
+		}
+		fmt.Fprintf(&b, " %s", item.MessageCode)
+		if item.Field != "" {
+			fmt.Fprintf(&b, " (%s)", item.Field)
+		}
+		if item.Message != "" {
+			fmt.Fprintf(&b, ": %s", item.Message)
+		}
+	}
+	return b.String()
+}
+
+// HasMessageCode 判断错误详情中是否包含指定错误码
+func (e *APIError) HasMessageCode(code ErrorResponseItemMessageCode) bool {
+	for _, item := range e.Errors {
+		if item.MessageCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// legacyErrorBody 旧版 code/message 格式的错误响应
+type legacyErrorBody struct {
+	Code    int64  `json:"code"`
+	Message string `json:"message"`
+}
+
+// newAPIError 根据响应构建 *APIError, 响应正常时返回nil
+func newAPIError(res *http.Response, content []byte) *APIError {
+	var (
+		body   APIErrorResponse
+		legacy legacyErrorBody
+	)
+	_ = json.Unmarshal(content, &body)
+	_ = json.Unmarshal(content, &legacy)
+
+	if res.StatusCode < http.StatusBadRequest && len(body.Error.Errors) == 0 && legacy.Code == 0 {
+		return nil
+	}
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Errors:     body.Error.Errors,
+		Message:    legacy.Message,
+		Body:       content,
+	}
+	if res.Request != nil {
+		apiErr.Method = res.Request.Method
+		apiErr.URL = res.Request.URL.String()
+	}
+	if apiErr.Message == "" && len(apiErr.Errors) == 0 {
+		apiErr.Message = http.StatusText(res.StatusCode)
+	}
+	return apiErr
+}
+
+// OAuthError 换取access token失败时的错误, 可以通过 errors.As 获取
+//
+// https://datatracker.ietf.org/doc/html/rfc6749#section-5.2
+type OAuthError struct {
+	// StatusCode http状态码
+	StatusCode int
+	// Code 错误码, 例如 invalid_client、invalid_grant
+	Code string `json:"error"`
+	// Description 错误描述
+	Description string `json:"error_description"`
+	// Body 原始响应内容
+	Body []byte `json:"-"`
+}
+
+func (e *OAuthError) Error() string {
+	msg := fmt.Sprintf("asa: oauth token exchange: %d %s", e.StatusCode, e.Code)
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	return msg
+}
+
+// newOAuthError 根据换取token的响应构建 *OAuthError, 响应正常时返回nil
+func newOAuthError(res *http.Response, content []byte) *OAuthError {
+	oauthErr := &OAuthError{}
+	_ = json.Unmarshal(content, oauthErr)
+	if res.StatusCode < http.StatusBadRequest && oauthErr.Code == "" {
+		return nil
+	}
+	oauthErr.StatusCode = res.StatusCode
+	oauthErr.Body = content
+	if oauthErr.Code == "" {
+		oauthErr.Code = http.StatusText(res.StatusCode)
+	}
+	return oauthErr
+}
+
+// asAPIError 从错误链中获取 *APIError
+func asAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+// IsNotFound 判断是否为资源不存在错误
+func IsNotFound(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsUnauthorized 判断是否为认证或权限错误, 包括换取access token失败
+func IsUnauthorized(err error) bool {
+	var oauthErr *OAuthError
+	if errors.As(err, &oauthErr) {
+		switch oauthErr.Code {
+		case "invalid_client", "invalid_grant", "unauthorized_client":
+			return true
+		}
+		return oauthErr.StatusCode == http.StatusUnauthorized || oauthErr.StatusCode == http.StatusForbidden
+	}
+	apiErr, ok := asAPIError(err)
+	return ok && (apiErr.StatusCode == http.StatusUnauthorized ||
+		apiErr.StatusCode == http.StatusForbidden ||
+		apiErr.HasMessageCode(ErrorResponseItemMessageCodeUnauthorized))
+}
+
+// IsRateLimited 判断是否触发了接口限流
+func IsRateLimited(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// IsValidation 判断是否为请求参数校验错误
+func IsValidation(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && (apiErr.StatusCode == http.StatusBadRequest ||
+		apiErr.StatusCode == http.StatusUnprocessableEntity ||
+		apiErr.HasMessageCode(ErrorResponseItemMessageCodeInvalidDateFormat))
+}
@@ -0,0 +1,54 @@
+package asa
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -run TestAPIError
+func TestAPIError(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"data":null,"pagination":null,"error":{"errors":[{"messageCode":"INVALID_DATE_FORMAT","message":"bad date","field":"startTime"}]}}`))
+	})
+
+	_, err := c.Campaigns.CreateCampaign(&Campaign{Name: "test"})
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, http.MethodPost, apiErr.Method)
+	assert.Contains(t, apiErr.URL, "/campaigns")
+	assert.Len(t, apiErr.Errors, 1)
+	assert.Equal(t, "startTime", apiErr.Errors[0].Field)
+	assert.True(t, IsValidation(err))
+	assert.False(t, IsNotFound(err))
+	assert.Contains(t, err.Error(), "INVALID_DATE_FORMAT (startTime): bad date")
+}
+
+// go test -v -run TestAPIErrorStatus
+func TestAPIErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	status := map[string]int{
+		"/campaigns/1": http.StatusNotFound,
+		"/campaigns/2": http.StatusUnauthorized,
+		"/campaigns/3": http.StatusTooManyRequests,
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status[r.URL.Path])
+	})
+	c.SetRetryPolicy(nil)
+
+	_, err := c.Campaigns.GetCampaign(1)
+	assert.True(t, IsNotFound(err))
+	_, err = c.Campaigns.GetCampaign(2)
+	assert.True(t, IsUnauthorized(err))
+	_, err = c.Campaigns.GetCampaign(3)
+	assert.True(t, IsRateLimited(err))
+	assert.False(t, IsRateLimited(errors.New("429")))
+}
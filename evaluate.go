@@ -0,0 +1,598 @@
+package asa
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EvaluateSelector 在本地对items应用selector, 依次执行条件过滤、排序、分页与字段投影
+//
+// T 为 Campaign、AdGroup、Keyword、NegativeKeyword 等模型的结构体或结构体指针, 字段使用json名称。
+// 匹配规则尽量与服务端一致:
+//   - EQUALS、NOT_EQUALS、IN、GREATER_THAN、LESS_THAN、BETWEEN 按字段类型比较, 数字与时间按值比较,
+//     Money 比较金额; 字符串区分大小写
+//   - STARTSWITH、ENDSWITH、LIKE 以及字符串字段的 CONTAINS、CONTAINS_ANY、CONTAINS_ALL 不区分大小写,
+//     LIKE 中 % 匹配任意字符串、_ 匹配单个字符, 不含通配符时按包含匹配
+//   - 列表字段的 EQUALS、IN、CONTAINS、CONTAINS_ANY 在任一元素匹配时成立, CONTAINS_ALL 要求包含所有值
+//   - IS null 匹配空值(nil、零时间、空列表)
+//
+// Pagination.Limit 为0时返回Offset之后的所有记录。返回的 PageDetail 与服务端分页信息含义相同,
+// TotalResults 为过滤后的记录数。设置 Fields 时返回的记录为只包含这些字段的副本, 否则返回原记录。
+// selector无效或字段不支持比较时返回 *SelectorError。
+func EvaluateSelector[T any](selector *Selector, items []T) ([]T, *PageDetail, error) {
+	typ, isPtr, err := evalItemType[T]()
+	if err != nil {
+		return nil, nil, err
+	}
+	if selector == nil {
+		selector = &Selector{}
+	}
+	ev, err := compileSelector(typ, selector)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	type entry struct {
+		item  T
+		value reflect.Value
+	}
+	var matched []entry
+	for _, item := range items {
+		v, ok := evalItemValue(item, isPtr)
+		if ok && ev.match(v) {
+			matched = append(matched, entry{item: item, value: v})
+		}
+	}
+	if len(ev.orderBy) > 0 {
+		sort.SliceStable(matched, func(i, j int) bool {
+			return ev.less(matched[i].value, matched[j].value)
+		})
+	}
+
+	page := &PageDetail{TotalResults: len(matched)}
+	start, end := 0, len(matched)
+	if p := selector.Pagination; p != nil {
+		page.StartIndex = int(p.Offset)
+		start = minInt(int(p.Offset), len(matched))
+		if p.Limit > 0 {
+			end = minInt(start+int(p.Limit), len(matched))
+		}
+	}
+	result := make([]T, 0, end-start)
+	for _, e := range matched[start:end] {
+		if len(ev.fields) == 0 {
+			result = append(result, e.item)
+			continue
+		}
+		result = append(result, ev.project(e.value, isPtr).Interface().(T))
+	}
+	page.ItemsPerPage = len(result)
+	return result, page, nil
+}
+
+// MatchSelector 判断item是否满足selector中的所有条件, 忽略排序、分页与字段
+func MatchSelector[T any](selector *Selector, item T) (bool, error) {
+	typ, isPtr, err := evalItemType[T]()
+	if err != nil {
+		return false, err
+	}
+	if selector == nil {
+		return true, nil
+	}
+	ev, err := compileSelector(typ, &Selector{Conditions: selector.Conditions})
+	if err != nil {
+		return false, err
+	}
+	v, ok := evalItemValue(item, isPtr)
+	return ok && ev.match(v), nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// evalItemType T对应的结构体类型
+func evalItemType[T any]() (reflect.Type, bool, error) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	isPtr := typ.Kind() == reflect.Ptr
+	if isPtr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, false, fmt.Errorf("asa: evaluate selector: unsupported item type %s", reflect.TypeOf((*T)(nil)).Elem())
+	}
+	return typ, isPtr, nil
+}
+
+// evalItemValue item对应的结构体值, nil指针返回false
+func evalItemValue[T any](item T, isPtr bool) (reflect.Value, bool) {
+	v := reflect.ValueOf(&item).Elem()
+	if isPtr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// evalKind 字段值的比较方式
+type evalKind int
+
+const (
+	evalString evalKind = iota
+	evalNumber
+	evalBool
+	evalTime
+)
+
+// evalValue 可比较的字段值
+type evalValue struct {
+	s string
+	n float64
+	b bool
+	t time.Time
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	moneyType = reflect.TypeOf(Money{})
+)
+
+// evalFieldKind 根据字段类型确定比较方式, list表示字段为列表
+func evalFieldKind(typ reflect.Type) (kind evalKind, list bool, ok bool) {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		list = true
+		typ = typ.Elem()
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return evalString, list, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return evalNumber, list, true
+	case reflect.Bool:
+		return evalBool, list, true
+	case reflect.Struct:
+		if typ == moneyType {
+			return evalNumber, list, true
+		}
+		if isTimeStruct(typ) {
+			return evalTime, list, true
+		}
+	}
+	return 0, false, false
+}
+
+// isTimeStruct 判断是否为 time.Time 或 DateTime 等内嵌 time.Time 的类型
+func isTimeStruct(typ reflect.Type) bool {
+	if typ == timeType {
+		return true
+	}
+	f, ok := typ.FieldByName("Time")
+	return ok && f.Anonymous && f.Type == timeType
+}
+
+// evalFieldValues 读取字段值, 空值(nil、零时间、空金额)返回空列表
+func evalFieldValues(v reflect.Value) []evalValue {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		values := make([]evalValue, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, evalFieldValues(v.Index(i))...)
+		}
+		return values
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return []evalValue{{s: v.String()}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []evalValue{{n: float64(v.Int())}}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []evalValue{{n: float64(v.Uint())}}
+	case reflect.Float32, reflect.Float64:
+		return []evalValue{{n: v.Float()}}
+	case reflect.Bool:
+		return []evalValue{{b: v.Bool()}}
+	case reflect.Struct:
+		if v.Type() == moneyType {
+			n, err := strconv.ParseFloat(v.Interface().(Money).Amount, 64)
+			if err != nil {
+				return nil
+			}
+			return []evalValue{{n: n}}
+		}
+		t := v.Interface()
+		if v.Type() != timeType {
+			t = v.FieldByName("Time").Interface()
+		}
+		if t.(time.Time).IsZero() {
+			return nil
+		}
+		return []evalValue{{t: t.(time.Time)}}
+	}
+	return nil
+}
+
+// evalTimeLayouts 条件中时间值支持的格式
+var evalTimeLayouts = []string{
+	customISO8601Format,
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	ReqDateFormat,
+}
+
+// parseEvalValue 按字段的比较方式解析条件中的值
+func parseEvalValue(kind evalKind, s string) (evalValue, error) {
+	switch kind {
+	case evalNumber:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return evalValue{}, fmt.Errorf("invalid number %q", s)
+		}
+		return evalValue{n: n}, nil
+	case evalBool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return evalValue{}, fmt.Errorf("invalid boolean %q", s)
+		}
+		return evalValue{b: b}, nil
+	case evalTime:
+		for _, layout := range evalTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return evalValue{t: t}, nil
+			}
+		}
+		return evalValue{}, fmt.Errorf("invalid time %q", s)
+	}
+	return evalValue{s: s}, nil
+}
+
+// compareEval 比较两个相同类型的值
+func compareEval(kind evalKind, a, b evalValue) int {
+	switch kind {
+	case evalNumber:
+		switch {
+		case a.n < b.n:
+			return -1
+		case a.n > b.n:
+			return 1
+		}
+		return 0
+	case evalBool:
+		switch {
+		case a.b == b.b:
+			return 0
+		case !a.b:
+			return -1
+		}
+		return 1
+	case evalTime:
+		return a.t.Compare(b.t)
+	}
+	return strings.Compare(a.s, b.s)
+}
+
+// evalFields 结构体json字段名到字段下标的缓存
+var evalFields sync.Map
+
+func evalFieldIndex(typ reflect.Type) map[string][]int {
+	if cached, ok := evalFields.Load(typ); ok {
+		return cached.(map[string][]int)
+	}
+	index := make(map[string][]int, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		if name := jsonFieldName(typ.Field(i)); name != "" {
+			index[name] = typ.Field(i).Index
+		}
+	}
+	evalFields.Store(typ, index)
+	return index
+}
+
+// evalCondition 预先解析字段与值的条件
+type evalCondition struct {
+	*Condition
+	index    []int
+	kind     evalKind
+	list     bool
+	operands []evalValue
+	isNull   bool
+	text     bool
+	like     *regexp.Regexp
+}
+
+type evalSorting struct {
+	index []int
+	kind  evalKind
+	desc  bool
+}
+
+// evaluator 针对某个结构体类型编译后的selector
+type evaluator struct {
+	conditions []*evalCondition
+	orderBy    []evalSorting
+	fields     [][]int
+}
+
+// textOperators 按文本匹配的操作符, 不区分大小写
+var textOperators = map[ConditionOperator]bool{
+	ConditionOperatorStartsWith: true,
+	ConditionOperatorEndsWith:   true,
+	ConditionOperatorLike:       true,
+}
+
+func compileSelector(typ reflect.Type, selector *Selector) (*evaluator, error) {
+	fieldIndex := evalFieldIndex(typ)
+	selErr := &SelectorError{}
+	problem := func(format string, args ...interface{}) {
+		selErr.Problems = append(selErr.Problems, fmt.Sprintf(format, args...))
+	}
+	lookup := func(kind, field string) (reflect.StructField, bool) {
+		index, ok := fieldIndex[field]
+		if !ok {
+			problem("%s: unknown %s field %q", kind, typ.Name(), field)
+			return reflect.StructField{}, false
+		}
+		return typ.FieldByIndex(index), true
+	}
+
+	ev := &evaluator{}
+	for _, cond := range selector.Conditions {
+		if cond == nil {
+			continue
+		}
+		if err := validateCondition(cond); err != nil {
+			problem("condition %s", err)
+			continue
+		}
+		field, ok := lookup("condition", cond.Field)
+		if !ok {
+			continue
+		}
+		kind, list, ok := evalFieldKind(field.Type)
+		if !ok {
+			problem("condition %s: field of type %s cannot be filtered", cond.Field, field.Type)
+			continue
+		}
+		c := &evalCondition{Condition: cond, index: field.Index, kind: kind, list: list}
+		if err := c.compile(); err != nil {
+			problem("condition %s: %s", cond.Field, err)
+			continue
+		}
+		ev.conditions = append(ev.conditions, c)
+	}
+	for _, sorting := range selector.OrderBy {
+		if sorting == nil {
+			continue
+		}
+		field, ok := lookup("orderBy", sorting.Field)
+		if !ok {
+			continue
+		}
+		kind, list, ok := evalFieldKind(field.Type)
+		if !ok || list {
+			problem("orderBy %s: field of type %s cannot be sorted", sorting.Field, field.Type)
+			continue
+		}
+		ev.orderBy = append(ev.orderBy, evalSorting{index: field.Index, kind: kind, desc: sorting.SortOrder == SortingOrderDescending})
+	}
+	for _, name := range selector.Fields {
+		if field, ok := lookup("fields", name); ok {
+			ev.fields = append(ev.fields, field.Index)
+		}
+	}
+	if len(selErr.Problems) > 0 {
+		return nil, selErr
+	}
+	return ev, nil
+}
+
+// compile 解析条件中的值
+func (c *evalCondition) compile() error {
+	switch {
+	case c.Operator == ConditionOperatorIs && strings.EqualFold(c.Values[0], "null"):
+		c.isNull = true
+		return nil
+	case textOperators[c.Operator] || (!c.list && isContainsOperator(c.Operator)):
+		if c.kind != evalString {
+			return fmt.Errorf("%s requires a text field", c.Operator)
+		}
+		c.text = true
+		if c.Operator == ConditionOperatorLike {
+			c.like = likePattern(c.Values[0])
+		}
+		for _, v := range c.Values {
+			c.operands = append(c.operands, evalValue{s: strings.ToLower(v)})
+		}
+		return nil
+	}
+	for _, v := range c.Values {
+		operand, err := parseEvalValue(c.kind, v)
+		if err != nil {
+			return err
+		}
+		c.operands = append(c.operands, operand)
+	}
+	return nil
+}
+
+func isContainsOperator(op ConditionOperator) bool {
+	return op == ConditionOperatorContains || op == ConditionOperatorContainsAny || op == ConditionOperatorContainsAll
+}
+
+// likePattern 将LIKE的模式转换为正则, % 匹配任意字符串, _ 匹配单个字符
+func likePattern(pattern string) *regexp.Regexp {
+	if !strings.ContainsAny(pattern, "%_") {
+		return regexp.MustCompile("(?is)" + regexp.QuoteMeta(pattern))
+	}
+	var b strings.Builder
+	b.WriteString("(?is)^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+func (ev *evaluator) match(v reflect.Value) bool {
+	for _, c := range ev.conditions {
+		if !c.match(evalFieldValues(v.FieldByIndex(c.index))) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *evalCondition) match(values []evalValue) bool {
+	if c.isNull {
+		return len(values) == 0
+	}
+	if c.text {
+		return c.matchText(values)
+	}
+	// anyValue 任一字段值满足fn
+	anyValue := func(fn func(v evalValue) bool) bool {
+		for _, v := range values {
+			if fn(v) {
+				return true
+			}
+		}
+		return false
+	}
+	equals := func(operand evalValue) bool {
+		return anyValue(func(v evalValue) bool { return compareEval(c.kind, v, operand) == 0 })
+	}
+	switch c.Operator {
+	case ConditionOperatorEquals, ConditionOperatorIs, ConditionOperatorContains:
+		return equals(c.operands[0])
+	case ConditionOperatorNotEqual:
+		return !equals(c.operands[0])
+	case ConditionOperatorIn, ConditionOperatorContainsAny:
+		for _, operand := range c.operands {
+			if equals(operand) {
+				return true
+			}
+		}
+		return false
+	case ConditionOperatorContainsAll:
+		for _, operand := range c.operands {
+			if !equals(operand) {
+				return false
+			}
+		}
+		return true
+	case ConditionOperatorGreaterThan:
+		return anyValue(func(v evalValue) bool { return compareEval(c.kind, v, c.operands[0]) > 0 })
+	case ConditionOperatorLessThan:
+		return anyValue(func(v evalValue) bool { return compareEval(c.kind, v, c.operands[0]) < 0 })
+	case ConditionOperatorBetween:
+		return anyValue(func(v evalValue) bool {
+			return compareEval(c.kind, v, c.operands[0]) >= 0 && compareEval(c.kind, v, c.operands[1]) <= 0
+		})
+	}
+	return false
+}
+
+// matchText 文本匹配, 列表字段任一元素匹配即可
+func (c *evalCondition) matchText(values []evalValue) bool {
+	for _, v := range values {
+		s := strings.ToLower(v.s)
+		switch c.Operator {
+		case ConditionOperatorStartsWith:
+			if strings.HasPrefix(s, c.operands[0].s) {
+				return true
+			}
+		case ConditionOperatorEndsWith:
+			if strings.HasSuffix(s, c.operands[0].s) {
+				return true
+			}
+		case ConditionOperatorLike:
+			if c.like.MatchString(v.s) {
+				return true
+			}
+		case ConditionOperatorContains, ConditionOperatorContainsAny:
+			for _, operand := range c.operands {
+				if strings.Contains(s, operand.s) {
+					return true
+				}
+			}
+		case ConditionOperatorContainsAll:
+			all := true
+			for _, operand := range c.operands {
+				all = all && strings.Contains(s, operand.s)
+			}
+			if all {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// less 按orderBy比较两条记录, 空值排在升序的最前面
+func (ev *evaluator) less(a, b reflect.Value) bool {
+	for _, sorting := range ev.orderBy {
+		av := evalFieldValues(a.FieldByIndex(sorting.index))
+		bv := evalFieldValues(b.FieldByIndex(sorting.index))
+		var cmp int
+		switch {
+		case len(av) == 0 && len(bv) == 0:
+			cmp = 0
+		case len(av) == 0:
+			cmp = -1
+		case len(bv) == 0:
+			cmp = 1
+		default:
+			cmp = compareEval(sorting.kind, av[0], bv[0])
+		}
+		if sorting.desc {
+			cmp = -cmp
+		}
+		if cmp != 0 {
+			return cmp < 0
+		}
+	}
+	return false
+}
+
+// project 复制记录中 Fields 指定的字段
+func (ev *evaluator) project(v reflect.Value, isPtr bool) reflect.Value {
+	out := reflect.New(v.Type())
+	for _, index := range ev.fields {
+		out.Elem().FieldByIndex(index).Set(v.FieldByIndex(index))
+	}
+	if isPtr {
+		return out
+	}
+	return out.Elem()
+}
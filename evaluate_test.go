@@ -0,0 +1,144 @@
+package asa
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testCampaigns() []*Campaign {
+	day := func(d int) DateTime {
+		return DateTime{Time: time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)}
+	}
+	return []*Campaign{
+		{ID: 1, Name: "US_Brand", Status: CampaignStatusEnabled, CountriesOrRegions: []string{"US"},
+			DailyBudgetAmount: &Money{Amount: "100", Currency: "USD"}, ModificationTime: day(3)},
+		{ID: 2, Name: "GB_Generic", Status: CampaignStatusPaused, CountriesOrRegions: []string{"GB", "US"},
+			DailyBudgetAmount: &Money{Amount: "50.5", Currency: "USD"}, ModificationTime: day(1)},
+		{ID: 3, Name: "us_competitor", Status: CampaignStatusEnabled, CountriesOrRegions: []string{"US", "CA"},
+			ModificationTime: day(2)},
+		nil,
+		{ID: 4, Name: "JP Brand", Status: CampaignStatusEnabled, CountriesOrRegions: []string{"JP"},
+			DailyBudgetAmount: &Money{Amount: "20", Currency: "JPY"}, ModificationTime: day(4), Deleted: true},
+	}
+}
+
+// go test -v -run TestEvaluateSelectorConditions
+func TestEvaluateSelectorConditions(t *testing.T) {
+	t.Parallel()
+
+	campaigns := testCampaigns()
+	cases := []struct {
+		query string
+		ids   []int64
+	}{
+		{`status = ENABLED`, []int64{1, 3, 4}},
+		{`status != ENABLED`, []int64{2}},
+		{`status = enabled`, nil},
+		{`id in (2, 4, 9)`, []int64{2, 4}},
+		{`id between 2 and 3`, []int64{2, 3}},
+		{`id > 3`, []int64{4}},
+		{`id < 2`, []int64{1}},
+		{`name startswith us_`, []int64{1, 3}},
+		{`name endswith BRAND`, []int64{1, 4}},
+		{`name like "%brand"`, []int64{1, 4}},
+		{`name like "us%"`, []int64{1, 3}},
+		{`name like "_P %"`, []int64{4}},
+		{`name like comp`, []int64{3}},
+		{`name contains GEN`, []int64{2}},
+		{`name contains_any (gen, jp)`, []int64{2, 4}},
+		{`name contains_all (us, comp)`, []int64{3}},
+		{`countriesOrRegions = US`, []int64{1, 2, 3}},
+		{`countriesOrRegions contains CA`, []int64{3}},
+		{`countriesOrRegions in (JP, CA)`, []int64{3, 4}},
+		{`countriesOrRegions contains_any (GB, JP)`, []int64{2, 4}},
+		{`countriesOrRegions contains_all (US, GB)`, []int64{2}},
+		{`dailyBudgetAmount > 50`, []int64{1, 2}},
+		{`dailyBudgetAmount is null`, []int64{3}},
+		{`deleted is true`, []int64{4}},
+		{`modificationTime between 2024-01-02 and "2024-01-03T00:00:00.000"`, []int64{1, 3}},
+		{`status = ENABLED and countriesOrRegions = US`, []int64{1, 3}},
+	}
+	for _, c := range cases {
+		selector, err := ParseSelector(c.query)
+		if !assert.NoError(t, err, c.query) {
+			continue
+		}
+		result, page, err := EvaluateSelector(selector, campaigns)
+		if !assert.NoError(t, err, c.query) {
+			continue
+		}
+		var ids []int64
+		for _, campaign := range result {
+			ids = append(ids, campaign.ID)
+		}
+		assert.Equal(t, c.ids, ids, c.query)
+		assert.Equal(t, len(c.ids), page.TotalResults, c.query)
+	}
+
+	ok, err := MatchSelector(&Selector{Conditions: []*Condition{{Field: "text", Operator: ConditionOperatorEquals, Values: []string{"shoes"}}}},
+		Keyword{Text: "shoes"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+// go test -v -run TestEvaluateSelectorOrderAndPage
+func TestEvaluateSelectorOrderAndPage(t *testing.T) {
+	t.Parallel()
+
+	campaigns := testCampaigns()
+	selector, err := ParseSelector(`status = ENABLED fields id, name order by modificationTime desc limit 2 offset 1`)
+	assert.NoError(t, err)
+	result, page, err := EvaluateSelector(selector, campaigns)
+	assert.NoError(t, err)
+	assert.Equal(t, &PageDetail{TotalResults: 3, StartIndex: 1, ItemsPerPage: 2}, page)
+	assert.Equal(t, []*Campaign{{ID: 1, Name: "US_Brand"}, {ID: 3, Name: "us_competitor"}}, result)
+	// 投影返回副本, 不修改原记录
+	assert.Equal(t, CampaignStatusEnabled, campaigns[0].Status)
+
+	// 空值在升序时排在最前面
+	values := []Campaign{*campaigns[0], *campaigns[1], *campaigns[2]}
+	sorted, _, err := EvaluateSelector(NewCampaignSelector().OrderBy("dailyBudgetAmount", SortingOrderAscending).MustBuild(), values)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 2, 1}, []int64{sorted[0].ID, sorted[1].ID, sorted[2].ID})
+
+	sorted, page, err = EvaluateSelector(&Selector{Pagination: &Pagination{Offset: 10}}, values)
+	assert.NoError(t, err)
+	assert.Empty(t, sorted)
+	assert.Equal(t, &PageDetail{TotalResults: 3, StartIndex: 10}, page)
+}
+
+// go test -v -run TestEvaluateSelectorErrors
+func TestEvaluateSelectorErrors(t *testing.T) {
+	t.Parallel()
+
+	selector := &Selector{
+		Conditions: []*Condition{
+			{Field: "budget", Operator: ConditionOperatorEquals, Values: []string{"1"}},
+			{Field: "id", Operator: ConditionOperatorEquals, Values: []string{"abc"}},
+			{Field: "id", Operator: ConditionOperatorStartsWith, Values: []string{"1"}},
+			{Field: "id", Operator: ConditionOperatorBetween, Values: []string{"1"}},
+			{Field: "locInvoiceDetails", Operator: ConditionOperatorEquals, Values: []string{"x"}},
+		},
+		OrderBy: []*Sorting{{Field: "countriesOrRegions", SortOrder: SortingOrderAscending}},
+		Fields:  []string{"unknown"},
+	}
+	_, _, err := EvaluateSelector(selector, testCampaigns())
+	var selErr *SelectorError
+	if assert.True(t, errors.As(err, &selErr)) {
+		assert.Equal(t, []string{
+			`condition: unknown Campaign field "budget"`,
+			`condition id: invalid number "abc"`,
+			"condition id: STARTSWITH requires a text field",
+			"condition id: BETWEEN requires 2 value(s), got 1",
+			"condition locInvoiceDetails: field of type *asa.LOCInvoiceDetails cannot be filtered",
+			"orderBy countriesOrRegions: field of type []string cannot be sorted",
+			`fields: unknown Campaign field "unknown"`,
+		}, selErr.Problems)
+	}
+
+	_, _, err = EvaluateSelector(nil, []string{"a"})
+	assert.Error(t, err)
+}
@@ -1,5 +1,7 @@
 package asa
 
+import "context"
+
 // GeoService handles communication with build-related methods of the Apple Search Ads API
 //
 // https://developer.apple.com/documentation/apple_search_ads/search_apps_and_geolocations
@@ -62,20 +64,49 @@ type SearchEntity struct {
 //
 // https://developer.apple.com/documentation/apple_search_ads/search_for_geolocations
 func (s *GeoService) SearchGeos(params *SearchGeoQuery) (*SearchEntityListResponse, error) {
+	return s.SearchGeosWithContext(context.Background(), params)
+}
+
+// SearchGeosWithContext is like SearchGeos but uses ctx to cancel the request or bound its deadline.
+func (s *GeoService) SearchGeosWithContext(ctx context.Context, params *SearchGeoQuery) (*SearchEntityListResponse, error) {
 	url := "search/geo"
 	res := new(SearchEntityListResponse)
-	err := s.client.get(url, res, params)
+	err := s.client.get(ctx, url, res, params)
 
 	return res, err
 }
 
+// SearchGeosPager returns a Pager that walks all geolocations matching params page by page via SearchGeos.
+// Limit and Offset in params set the page size and the starting offset.
+func (s *GeoService) SearchGeosPager(params *SearchGeoQuery) *Pager[*SearchEntity] {
+	query := SearchGeoQuery{}
+	if params != nil {
+		query = *params
+	}
+	p := NewPager(func(ctx context.Context, offset, limit int) ([]*SearchEntity, *PageDetail, error) {
+		q := query
+		q.Offset, q.Limit = int32(offset), int32(limit)
+		res, err := s.SearchGeosWithContext(ctx, &q)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.SearchEntities, res.Pagination, nil
+	})
+	return p.Offset(int(query.Offset)).PageSize(int(query.Limit))
+}
+
 // GetGeos Gets geolocation details using a geoidentifier
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_a_list_of_geolocations
 func (s *GeoService) GetGeos(query *ListGeoQuery, params []*GeoRequest) (*SearchEntityListResponse, error) {
+	return s.GetGeosWithContext(context.Background(), query, params)
+}
+
+// GetGeosWithContext is like GetGeos but uses ctx to cancel the request or bound its deadline.
+func (s *GeoService) GetGeosWithContext(ctx context.Context, query *ListGeoQuery, params []*GeoRequest) (*SearchEntityListResponse, error) {
 	url := "search/geo"
 	res := new(SearchEntityListResponse)
-	err := s.client.postWithQuery(url, res, query, params)
+	err := s.client.postWithQuery(ctx, url, res, query, params)
 
 	return res, err
 }
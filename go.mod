@@ -8,10 +8,10 @@ require (
 	github.com/google/go-querystring v1.1.0
 	github.com/ropon/requests/v2 v2.3.1
 	github.com/stretchr/testify v1.9.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
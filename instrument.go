@@ -0,0 +1,82 @@
+package asa
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// CallInfo 一次接口调用的信息, 包含该调用内的所有重试
+type CallInfo struct {
+	// Service 服务名, 例如 CampaignService
+	Service string
+	// Operation 操作名, 例如 CampaignService.UpdateCampaign
+	Operation string
+	Method    string
+	// URL 相对于基础地址的接口路径
+	URL string
+	// OrgID 本次调用使用的组织ID
+	OrgID int64
+	// Attempts 实际发出的请求次数
+	Attempts int
+	// RateLimited 收到429的次数
+	RateLimited int
+	// StatusCode 最后一次响应的http状态码, 请求失败时为0
+	StatusCode int
+	// Latency 调用总耗时, 包含重试等待
+	Latency time.Duration
+	// Err 调用最终返回的错误
+	Err error
+}
+
+// CallHook 接口调用回调, 每次接口调用只触发一次, 适合接入链路追踪与监控
+//
+// BeforeCall 按注册顺序调用, 返回的ctx用于本次调用的后续请求(包括换取access token);
+// AfterCall 按注册的逆序调用。同一次调用中两个回调收到的是同一个 *CallInfo。
+type CallHook struct {
+	BeforeCall func(ctx context.Context, info *CallInfo) context.Context
+	AfterCall  func(ctx context.Context, info *CallInfo)
+}
+
+// UseCallHook 追加接口调用回调
+func (c *Client) UseCallHook(hooks ...CallHook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// 复制后追加, 不影响共享同一切片的子客户端和进行中的请求
+	hs := make([]CallHook, 0, len(c.callHooks)+len(hooks))
+	c.callHooks = append(append(hs, c.callHooks...), hooks...)
+}
+
+// WithCallHook 设置接口调用回调
+func WithCallHook(hooks ...CallHook) Option {
+	return func(c *Client) error {
+		c.callHooks = append(c.callHooks, hooks...)
+		return nil
+	}
+}
+
+// pkgPath 本包的导入路径, 用于从调用栈中识别服务方法
+var pkgPath = reflect.TypeOf(Client{}).PkgPath()
+
+// callerOperation 从调用栈中找到最近的服务方法, 返回服务名与操作名
+func callerOperation() (service, operation string) {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	prefix := pkgPath + ".(*"
+	for {
+		frame, more := frames.Next()
+		if name := strings.TrimPrefix(frame.Function, prefix); name != frame.Function {
+			// 形如 CampaignService).UpdateCampaignWithContext
+			if svc, method, ok := strings.Cut(name, ")."); ok && strings.HasSuffix(svc, "Service") {
+				method = strings.TrimSuffix(method, "WithContext")
+				return svc, svc + "." + method
+			}
+		}
+		if !more {
+			return "", ""
+		}
+	}
+}
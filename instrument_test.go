@@ -0,0 +1,55 @@
+package asa
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCtxKey struct{}
+
+// go test -v -run TestCallHook
+func TestCallHook(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	})
+	c.SetRetryPolicy(fastRetryPolicy())
+
+	var infos []*CallInfo
+	c.UseCallHook(CallHook{
+		BeforeCall: func(ctx context.Context, info *CallInfo) context.Context {
+			return context.WithValue(ctx, testCtxKey{}, "span")
+		},
+		AfterCall: func(ctx context.Context, info *CallInfo) {
+			assert.Equal(t, "span", ctx.Value(testCtxKey{}))
+			infos = append(infos, info)
+		},
+	})
+
+	_, err := c.Campaigns.GetCampaign(1)
+	assert.NoError(t, err)
+	_, err = c.ForOrg(42).AdGroups.GetAdGroup(1, 2)
+	assert.NoError(t, err)
+
+	assert.Len(t, infos, 2)
+	assert.Equal(t, "CampaignService", infos[0].Service)
+	assert.Equal(t, "CampaignService.GetCampaign", infos[0].Operation)
+	assert.Equal(t, http.MethodGet, infos[0].Method)
+	assert.Equal(t, 2, infos[0].Attempts)
+	assert.Equal(t, 1, infos[0].RateLimited)
+	assert.Equal(t, http.StatusOK, infos[0].StatusCode)
+	assert.NoError(t, infos[0].Err)
+	assert.Equal(t, "AdGroupService.GetAdGroup", infos[1].Operation)
+	assert.Equal(t, int64(42), infos[1].OrgID)
+	assert.Equal(t, 1, infos[1].Attempts)
+}
@@ -0,0 +1,136 @@
+package asa
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrInvalidKeyCurve 私钥不是Apple要求的ECDSA P-256私钥
+var ErrInvalidKeyCurve = errors.New("private key is not an ECDSA P-256 key")
+
+// ParsePrivateKey 解析私钥, 支持以下格式:
+//   - SEC1 PEM("EC PRIVATE KEY")
+//   - PKCS#8 PEM("PRIVATE KEY"), 例如Apple后台下载的.p8文件
+//   - base64编码的上述PEM内容, 便于放在环境变量中
+//   - SEC1或PKCS#8的DER二进制内容
+//
+// 私钥必须是P-256曲线的ECDSA私钥, 否则返回 ErrInvalidKeyCurve; 内容不是上述任一格式时返回 ErrMissingPEM,
+// 是DER内容但解析失败时返回解析错误。
+func ParsePrivateKey(raw []byte) (*ecdsa.PrivateKey, error) {
+	// DER是二进制内容, 首尾可能恰好是空白字符, 只对文本格式去除空白
+	data := bytes.TrimSpace(raw)
+	if len(data) == 0 {
+		return nil, ErrMissingPEM
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		return parsePrivateKeyDER(block.Type, block.Bytes)
+	}
+	if decoded, err := decodeBase64(data); err == nil {
+		if block, _ := pem.Decode(decoded); block != nil {
+			return parsePrivateKeyDER(block.Type, block.Bytes)
+		}
+		if isDER(decoded) {
+			return parsePrivateKeyDER("", decoded)
+		}
+	}
+	if isDER(raw) {
+		return parsePrivateKeyDER("", raw)
+	}
+	return nil, ErrMissingPEM
+}
+
+// isDER 判断data是否可能是DER编码的私钥, SEC1与PKCS#8私钥均以ASN.1 SEQUENCE开头
+func isDER(data []byte) bool {
+	return len(data) > 1 && data[0] == 0x30
+}
+
+// ReadPrivateKey 从r中读取并解析私钥, 支持的格式同 ParsePrivateKey
+func ReadPrivateKey(r io.Reader) (*ecdsa.PrivateKey, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("asa: read private key: %w", err)
+	}
+	return ParsePrivateKey(data)
+}
+
+// LoadPrivateKeyFile 从文件中读取并解析私钥, 支持的格式同 ParsePrivateKey
+func LoadPrivateKeyFile(path string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("asa: read private key: %w", err)
+	}
+	key, err := ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("asa: parse private key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// PublicKeyPEM 生成私钥对应的PEM格式公钥, 用于上传到Apple Search Ads后台
+func PublicKeyPEM(key *ecdsa.PrivateKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// parsePrivateKeyDER 按PEM类型解析DER内容, 类型未知时依次尝试SEC1与PKCS#8
+func parsePrivateKeyDER(blockType string, der []byte) (*ecdsa.PrivateKey, error) {
+	var (
+		key *ecdsa.PrivateKey
+		err error
+	)
+	switch blockType {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(der)
+	case "PRIVATE KEY":
+		key, err = parsePKCS8PrivateKey(der)
+	case "":
+		if key, err = x509.ParseECPrivateKey(der); err != nil {
+			key, err = parsePKCS8PrivateKey(der)
+		}
+	default:
+		return nil, fmt.Errorf("asa: unsupported private key type %q", blockType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("%w: got %s", ErrInvalidKeyCurve, key.Curve.Params().Name)
+	}
+	return key, nil
+}
+
+// parsePKCS8PrivateKey 解析PKCS#8私钥, 只接受ECDSA私钥
+func parsePKCS8PrivateKey(der []byte) (*ecdsa.PrivateKey, error) {
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: got %T", ErrInvalidKeyCurve, parsed)
+	}
+	return key, nil
+}
+
+// decodeBase64 解码标准或url安全的base64内容, 忽略换行
+func decodeBase64(data []byte) ([]byte, error) {
+	s := strings.Join(strings.Fields(string(data)), "")
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if decoded, err := enc.DecodeString(s); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, errors.New("invalid base64")
+}
@@ -0,0 +1,146 @@
+package asa
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -run TestParsePrivateKey
+func TestParsePrivateKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sec1, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sec1PEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1})
+	pkcs8PEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})
+
+	cases := map[string][]byte{
+		"sec1 pem":    sec1PEM,
+		"pkcs8 pem":   pkcs8PEM,
+		"base64 pem":  []byte(base64.StdEncoding.EncodeToString(pkcs8PEM)),
+		"base64 der":  []byte(base64.StdEncoding.EncodeToString(pkcs8)),
+		"raw der":     sec1,
+		"padded pem":  append(append([]byte("\n  "), sec1PEM...), '\n'),
+		"wrapped b64": []byte(strings.Join(splitEvery(base64.StdEncoding.EncodeToString(sec1PEM), 64), "\n")),
+		"raw url b64": []byte(base64.RawURLEncoding.EncodeToString(pkcs8PEM)),
+	}
+	for name, data := range cases {
+		parsed, err := ParsePrivateKey(data)
+		if assert.NoError(t, err, name) {
+			assert.True(t, key.Equal(parsed), name)
+		}
+	}
+
+	_, err = ParsePrivateKey(nil)
+	assert.ErrorIs(t, err, ErrMissingPEM)
+	_, err = ParsePrivateKey([]byte("not a key"))
+	assert.ErrorIs(t, err, ErrMissingPEM)
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(p384)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	assert.ErrorIs(t, err, ErrInvalidKeyCurve)
+	_, err = NewTokenConfigFromKey("client", "team", "key", p384)
+	assert.ErrorIs(t, err, ErrInvalidKeyCurve)
+
+	// base64编码的非P-256私钥返回 ErrInvalidKeyCurve 而不是 ErrMissingPEM
+	p384SEC1, err := x509.MarshalECPrivateKey(p384)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaDER, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := map[string][]byte{
+		"base64 p384 der":  []byte(base64.StdEncoding.EncodeToString(der)),
+		"base64 p384 sec1": []byte(base64.StdEncoding.EncodeToString(p384SEC1)),
+		"base64 rsa der":   []byte(base64.StdEncoding.EncodeToString(rsaDER)),
+		"base64 rsa pem":   []byte(base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rsaDER}))),
+		"raw rsa der":      rsaDER,
+	}
+	// 首尾为空白字符的DER内容不会被截断
+	for i := 0; i < 50; i++ {
+		k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := ParsePrivateKey(der)
+		if assert.NoError(t, err) {
+			assert.True(t, k.Equal(parsed))
+		}
+	}
+	for name, data := range invalid {
+		_, err = ParsePrivateKey(data)
+		assert.ErrorIs(t, err, ErrInvalidKeyCurve, name)
+	}
+}
+
+// go test -v -run TestNewTokenConfigFromFile
+func TestNewTokenConfigFromFile(t *testing.T) {
+	t.Parallel()
+
+	keyPEM := newTestPrivateKey(t)
+	path := filepath.Join(t.TempDir(), "AuthKey.p8")
+	assert.NoError(t, os.WriteFile(path, []byte(keyPEM), 0o600))
+
+	fromFile, err := NewTokenConfigFromFile("client", "team", "key", path)
+	assert.NoError(t, err)
+	fromReader, err := NewTokenConfigFromReader("client", "team", "key", strings.NewReader(keyPEM))
+	assert.NoError(t, err)
+
+	pub, err := fromFile.PublicKeyPEM()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(pub, "-----BEGIN PUBLIC KEY-----"))
+	readerPub, err := fromReader.PublicKeyPEM()
+	assert.NoError(t, err)
+	assert.Equal(t, pub, readerPub)
+
+	_, err = NewTokenConfigFromFile("client", "team", "key", filepath.Join(t.TempDir(), "missing.p8"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// splitEvery 按固定长度切分字符串
+func splitEvery(s string, n int) []string {
+	var parts []string
+	for len(s) > n {
+		parts = append(parts, s[:n])
+		s = s[n:]
+	}
+	return append(parts, s)
+}
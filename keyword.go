@@ -1,6 +1,7 @@
 package asa
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -133,9 +134,39 @@ type IntegerResponse struct {
 //
 // https://developer.apple.com/documentation/apple_search_ads/create_targeting_keywords
 func (s *KeywordService) FindTargetingKeywords(campaignID int64, selector *Selector) (*KeywordListResponse, error) {
+	return s.FindTargetingKeywordsWithContext(context.Background(), campaignID, selector)
+}
+
+// FindTargetingKeywordsWithContext is like FindTargetingKeywords but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) FindTargetingKeywordsWithContext(ctx context.Context, campaignID int64, selector *Selector) (*KeywordListResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups/targetingkeywords/find", campaignID)
 	res := new(KeywordListResponse)
-	err := s.client.post(url, res, selector)
+	err := s.client.find(ctx, url, res, selector)
+
+	return res, err
+}
+
+// FindTargetingKeywordsPager returns a Pager that walks all targeting keywords matching selector page by page via FindTargetingKeywords.
+func (s *KeywordService) FindTargetingKeywordsPager(campaignID int64, selector *Selector) *Pager[*Keyword] {
+	return newSelectorPager(selector, func(ctx context.Context, offset, limit int) ([]*Keyword, *PageDetail, error) {
+		res, err := s.FindTargetingKeywordsWithContext(ctx, campaignID, pageSelector(selector, offset, limit))
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Keywords, res.Pagination, nil
+	})
+}
+
+// FindTargetingKeywordsProjected is like FindTargetingKeywords but reports which fields each record contains.
+func (s *KeywordService) FindTargetingKeywordsProjected(campaignID int64, selector *Selector) (*ProjectedListResponse[Keyword], error) {
+	return s.FindTargetingKeywordsProjectedWithContext(context.Background(), campaignID, selector)
+}
+
+// FindTargetingKeywordsProjectedWithContext is like FindTargetingKeywordsProjected but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) FindTargetingKeywordsProjectedWithContext(ctx context.Context, campaignID int64, selector *Selector) (*ProjectedListResponse[Keyword], error) {
+	url := fmt.Sprintf("campaigns/%d/adgroups/targetingkeywords/find", campaignID)
+	res := new(ProjectedListResponse[Keyword])
+	err := s.client.find(ctx, url, res, selector)
 
 	return res, err
 }
@@ -144,9 +175,14 @@ func (s *KeywordService) FindTargetingKeywords(campaignID int64, selector *Selec
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_a_targeting_keyword_in_an_ad_group
 func (s *KeywordService) GetTargetingKeyword(campaignID int64, adGroupID int64, keywordID int64) (*KeywordResponse, error) {
+	return s.GetTargetingKeywordWithContext(context.Background(), campaignID, adGroupID, keywordID)
+}
+
+// GetTargetingKeywordWithContext is like GetTargetingKeyword but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) GetTargetingKeywordWithContext(ctx context.Context, campaignID int64, adGroupID int64, keywordID int64) (*KeywordResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups/%d/targetingkeywords/%d", campaignID, adGroupID, keywordID)
 	res := new(KeywordResponse)
-	err := s.client.get(url, res)
+	err := s.client.get(ctx, url, res)
 
 	return res, err
 }
@@ -155,20 +191,41 @@ func (s *KeywordService) GetTargetingKeyword(campaignID int64, adGroupID int64,
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_all_targeting_keywords_in_an_ad_group
 func (s *KeywordService) GetAllTargetingKeywords(campaignID int64, adGroupID int64, params *GetAllTargetingKeywordsQuery) (*KeywordListResponse, error) {
+	return s.GetAllTargetingKeywordsWithContext(context.Background(), campaignID, adGroupID, params)
+}
+
+// GetAllTargetingKeywordsWithContext is like GetAllTargetingKeywords but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) GetAllTargetingKeywordsWithContext(ctx context.Context, campaignID int64, adGroupID int64, params *GetAllTargetingKeywordsQuery) (*KeywordListResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups/%d/targetingkeywords/", campaignID, adGroupID)
 	res := new(KeywordListResponse)
-	err := s.client.get(url, res, params)
+	err := s.client.get(ctx, url, res, params)
 
 	return res, err
 }
 
+// GetAllTargetingKeywordsPager returns a Pager that walks all targeting keywords of an ad group page by page via GetAllTargetingKeywords.
+func (s *KeywordService) GetAllTargetingKeywordsPager(campaignID int64, adGroupID int64) *Pager[*Keyword] {
+	return NewPager(func(ctx context.Context, offset, limit int) ([]*Keyword, *PageDetail, error) {
+		res, err := s.GetAllTargetingKeywordsWithContext(ctx, campaignID, adGroupID, &GetAllTargetingKeywordsQuery{Limit: int32(limit), Offset: int32(offset)})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Keywords, res.Pagination, nil
+	})
+}
+
 // CreateTargetingKeywords Creates targeting keywords in ad groups
 //
 // https://developer.apple.com/documentation/apple_search_ads/create_targeting_keywords
 func (s *KeywordService) CreateTargetingKeywords(campaignID int64, adGroupID int64, keyword []*Keyword) (*KeywordListResponse, error) {
+	return s.CreateTargetingKeywordsWithContext(context.Background(), campaignID, adGroupID, keyword)
+}
+
+// CreateTargetingKeywordsWithContext is like CreateTargetingKeywords but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) CreateTargetingKeywordsWithContext(ctx context.Context, campaignID int64, adGroupID int64, keyword []*Keyword) (*KeywordListResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups/%d/targetingkeywords/bulk", campaignID, adGroupID)
 	res := new(KeywordListResponse)
-	err := s.client.post(url, res, keyword)
+	err := s.client.post(ctx, url, res, keyword)
 
 	return res, err
 }
@@ -177,9 +234,14 @@ func (s *KeywordService) CreateTargetingKeywords(campaignID int64, adGroupID int
 //
 // https://developer.apple.com/documentation/apple_search_ads/update_targeting_keywords
 func (s *KeywordService) UpdateTargetingKeywords(campaignID int64, adGroupID int64, updateRequests []*KeywordUpdateRequest) (*KeywordListResponse, error) {
+	return s.UpdateTargetingKeywordsWithContext(context.Background(), campaignID, adGroupID, updateRequests)
+}
+
+// UpdateTargetingKeywordsWithContext is like UpdateTargetingKeywords but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) UpdateTargetingKeywordsWithContext(ctx context.Context, campaignID int64, adGroupID int64, updateRequests []*KeywordUpdateRequest) (*KeywordListResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups/%d/targetingkeywords/bulk", campaignID, adGroupID)
 	res := new(KeywordListResponse)
-	err := s.client.put(url, res, updateRequests)
+	err := s.client.put(ctx, url, res, updateRequests)
 
 	return res, err
 }
@@ -188,9 +250,39 @@ func (s *KeywordService) UpdateTargetingKeywords(campaignID int64, adGroupID int
 //
 // https://developer.apple.com/documentation/apple_search_ads/find_campaign_negative_keywords
 func (s *KeywordService) FindNegativeKeywords(campaignID int64, selector *Selector) (*NegativeKeywordListResponse, error) {
+	return s.FindNegativeKeywordsWithContext(context.Background(), campaignID, selector)
+}
+
+// FindNegativeKeywordsWithContext is like FindNegativeKeywords but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) FindNegativeKeywordsWithContext(ctx context.Context, campaignID int64, selector *Selector) (*NegativeKeywordListResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/negativekeywords/find", campaignID)
 	res := new(NegativeKeywordListResponse)
-	err := s.client.post(url, res, selector)
+	err := s.client.find(ctx, url, res, selector)
+
+	return res, err
+}
+
+// FindNegativeKeywordsPager returns a Pager that walks all campaign negative keywords matching selector page by page via FindNegativeKeywords.
+func (s *KeywordService) FindNegativeKeywordsPager(campaignID int64, selector *Selector) *Pager[*NegativeKeyword] {
+	return newSelectorPager(selector, func(ctx context.Context, offset, limit int) ([]*NegativeKeyword, *PageDetail, error) {
+		res, err := s.FindNegativeKeywordsWithContext(ctx, campaignID, pageSelector(selector, offset, limit))
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Keywords, res.Pagination, nil
+	})
+}
+
+// FindNegativeKeywordsProjected is like FindNegativeKeywords but reports which fields each record contains.
+func (s *KeywordService) FindNegativeKeywordsProjected(campaignID int64, selector *Selector) (*ProjectedListResponse[NegativeKeyword], error) {
+	return s.FindNegativeKeywordsProjectedWithContext(context.Background(), campaignID, selector)
+}
+
+// FindNegativeKeywordsProjectedWithContext is like FindNegativeKeywordsProjected but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) FindNegativeKeywordsProjectedWithContext(ctx context.Context, campaignID int64, selector *Selector) (*ProjectedListResponse[NegativeKeyword], error) {
+	url := fmt.Sprintf("campaigns/%d/negativekeywords/find", campaignID)
+	res := new(ProjectedListResponse[NegativeKeyword])
+	err := s.client.find(ctx, url, res, selector)
 
 	return res, err
 }
@@ -199,9 +291,39 @@ func (s *KeywordService) FindNegativeKeywords(campaignID int64, selector *Select
 //
 // https://developer.apple.com/documentation/apple_search_ads/find_ad_group_negative_keywords
 func (s *KeywordService) FindAdGroupNegativeKeywords(campaignID int64, selector *Selector) (*NegativeKeywordListResponse, error) {
+	return s.FindAdGroupNegativeKeywordsWithContext(context.Background(), campaignID, selector)
+}
+
+// FindAdGroupNegativeKeywordsWithContext is like FindAdGroupNegativeKeywords but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) FindAdGroupNegativeKeywordsWithContext(ctx context.Context, campaignID int64, selector *Selector) (*NegativeKeywordListResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups/negativekeywords/find", campaignID)
 	res := new(NegativeKeywordListResponse)
-	err := s.client.post(url, res, selector)
+	err := s.client.find(ctx, url, res, selector)
+
+	return res, err
+}
+
+// FindAdGroupNegativeKeywordsPager returns a Pager that walks all ad group negative keywords matching selector page by page via FindAdGroupNegativeKeywords.
+func (s *KeywordService) FindAdGroupNegativeKeywordsPager(campaignID int64, selector *Selector) *Pager[*NegativeKeyword] {
+	return newSelectorPager(selector, func(ctx context.Context, offset, limit int) ([]*NegativeKeyword, *PageDetail, error) {
+		res, err := s.FindAdGroupNegativeKeywordsWithContext(ctx, campaignID, pageSelector(selector, offset, limit))
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Keywords, res.Pagination, nil
+	})
+}
+
+// FindAdGroupNegativeKeywordsProjected is like FindAdGroupNegativeKeywords but reports which fields each record contains.
+func (s *KeywordService) FindAdGroupNegativeKeywordsProjected(campaignID int64, selector *Selector) (*ProjectedListResponse[NegativeKeyword], error) {
+	return s.FindAdGroupNegativeKeywordsProjectedWithContext(context.Background(), campaignID, selector)
+}
+
+// FindAdGroupNegativeKeywordsProjectedWithContext is like FindAdGroupNegativeKeywordsProjected but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) FindAdGroupNegativeKeywordsProjectedWithContext(ctx context.Context, campaignID int64, selector *Selector) (*ProjectedListResponse[NegativeKeyword], error) {
+	url := fmt.Sprintf("campaigns/%d/adgroups/negativekeywords/find", campaignID)
+	res := new(ProjectedListResponse[NegativeKeyword])
+	err := s.client.find(ctx, url, res, selector)
 
 	return res, err
 }
@@ -210,9 +332,14 @@ func (s *KeywordService) FindAdGroupNegativeKeywords(campaignID int64, selector
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_a_campaign_negative_keyword
 func (s *KeywordService) GetNegativeKeyword(campaignID int64, keywordID int64) (*NegativeKeywordResponse, error) {
+	return s.GetNegativeKeywordWithContext(context.Background(), campaignID, keywordID)
+}
+
+// GetNegativeKeywordWithContext is like GetNegativeKeyword but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) GetNegativeKeywordWithContext(ctx context.Context, campaignID int64, keywordID int64) (*NegativeKeywordResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/negativekeywords/%d", campaignID, keywordID)
 	res := new(NegativeKeywordResponse)
-	err := s.client.get(url, res)
+	err := s.client.get(ctx, url, res)
 
 	return res, err
 }
@@ -221,9 +348,14 @@ func (s *KeywordService) GetNegativeKeyword(campaignID int64, keywordID int64) (
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_an_ad_group_negative_keyword
 func (s *KeywordService) GetAdGroupNegativeKeyword(campaignID int64, adGroupID int64, keywordID int64) (*NegativeKeywordResponse, error) {
+	return s.GetAdGroupNegativeKeywordWithContext(context.Background(), campaignID, adGroupID, keywordID)
+}
+
+// GetAdGroupNegativeKeywordWithContext is like GetAdGroupNegativeKeyword but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) GetAdGroupNegativeKeywordWithContext(ctx context.Context, campaignID int64, adGroupID int64, keywordID int64) (*NegativeKeywordResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups/%d/negativekeywords/%d", campaignID, adGroupID, keywordID)
 	res := new(NegativeKeywordResponse)
-	err := s.client.get(url, res)
+	err := s.client.get(ctx, url, res)
 
 	return res, err
 }
@@ -232,31 +364,68 @@ func (s *KeywordService) GetAdGroupNegativeKeyword(campaignID int64, adGroupID i
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_all_campaign_negative_keywords
 func (s *KeywordService) GetAllNegativeKeywords(campaignID int64, params *GetAllNegativeKeywordsQuery) (*NegativeKeywordListResponse, error) {
+	return s.GetAllNegativeKeywordsWithContext(context.Background(), campaignID, params)
+}
+
+// GetAllNegativeKeywordsWithContext is like GetAllNegativeKeywords but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) GetAllNegativeKeywordsWithContext(ctx context.Context, campaignID int64, params *GetAllNegativeKeywordsQuery) (*NegativeKeywordListResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/negativekeywords/", campaignID)
 	res := new(NegativeKeywordListResponse)
-	err := s.client.get(url, res, params)
+	err := s.client.get(ctx, url, res, params)
 
 	return res, err
 }
 
+// GetAllNegativeKeywordsPager returns a Pager that walks all negative keywords of a campaign page by page via GetAllNegativeKeywords.
+func (s *KeywordService) GetAllNegativeKeywordsPager(campaignID int64) *Pager[*NegativeKeyword] {
+	return NewPager(func(ctx context.Context, offset, limit int) ([]*NegativeKeyword, *PageDetail, error) {
+		res, err := s.GetAllNegativeKeywordsWithContext(ctx, campaignID, &GetAllNegativeKeywordsQuery{Limit: int32(limit), Offset: int32(offset)})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Keywords, res.Pagination, nil
+	})
+}
+
 // GetAllAdGroupNegativeKeywords Fetches all negative keywords in ad groups
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_all_ad_group_negative_keywords
 func (s *KeywordService) GetAllAdGroupNegativeKeywords(campaignID int64, adGroupID int64, params *GetAllNegativeKeywordsQuery) (*NegativeKeywordListResponse, error) {
+	return s.GetAllAdGroupNegativeKeywordsWithContext(context.Background(), campaignID, adGroupID, params)
+}
+
+// GetAllAdGroupNegativeKeywordsWithContext is like GetAllAdGroupNegativeKeywords but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) GetAllAdGroupNegativeKeywordsWithContext(ctx context.Context, campaignID int64, adGroupID int64, params *GetAllNegativeKeywordsQuery) (*NegativeKeywordListResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups/%d/negativekeywords/", campaignID, adGroupID)
 	res := new(NegativeKeywordListResponse)
-	err := s.client.get(url, res, params)
+	err := s.client.get(ctx, url, res, params)
 
 	return res, err
 }
 
+// GetAllAdGroupNegativeKeywordsPager returns a Pager that walks all negative keywords of an ad group page by page via GetAllAdGroupNegativeKeywords.
+func (s *KeywordService) GetAllAdGroupNegativeKeywordsPager(campaignID int64, adGroupID int64) *Pager[*NegativeKeyword] {
+	return NewPager(func(ctx context.Context, offset, limit int) ([]*NegativeKeyword, *PageDetail, error) {
+		res, err := s.GetAllAdGroupNegativeKeywordsWithContext(ctx, campaignID, adGroupID, &GetAllNegativeKeywordsQuery{Limit: int32(limit), Offset: int32(offset)})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Keywords, res.Pagination, nil
+	})
+}
+
 // CreateNegativeKeywords Creates negative keywords for a campaign
 //
 // https://developer.apple.com/documentation/apple_search_ads/create_campaign_negative_keywords
 func (s *KeywordService) CreateNegativeKeywords(campaignID int64, keyword []*NegativeKeyword) (*NegativeKeywordListResponse, error) {
+	return s.CreateNegativeKeywordsWithContext(context.Background(), campaignID, keyword)
+}
+
+// CreateNegativeKeywordsWithContext is like CreateNegativeKeywords but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) CreateNegativeKeywordsWithContext(ctx context.Context, campaignID int64, keyword []*NegativeKeyword) (*NegativeKeywordListResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/negativekeywords/bulk", campaignID)
 	res := new(NegativeKeywordListResponse)
-	err := s.client.post(url, res, keyword)
+	err := s.client.post(ctx, url, res, keyword)
 
 	return res, err
 }
@@ -265,9 +434,14 @@ func (s *KeywordService) CreateNegativeKeywords(campaignID int64, keyword []*Neg
 //
 // https://developer.apple.com/documentation/apple_search_ads/delete_campaign_negative_keywords
 func (s *KeywordService) DeleteNegativeKeywords(campaignID int64, keywordIds []int64) (*IntegerResponse, error) {
+	return s.DeleteNegativeKeywordsWithContext(context.Background(), campaignID, keywordIds)
+}
+
+// DeleteNegativeKeywordsWithContext is like DeleteNegativeKeywords but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) DeleteNegativeKeywordsWithContext(ctx context.Context, campaignID int64, keywordIds []int64) (*IntegerResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/negativekeywords/delete/bulk", campaignID)
 	res := new(IntegerResponse)
-	err := s.client.post(url, res, keywordIds)
+	err := s.client.post(ctx, url, res, keywordIds)
 
 	return res, err
 }
@@ -276,9 +450,14 @@ func (s *KeywordService) DeleteNegativeKeywords(campaignID int64, keywordIds []i
 //
 // https://developer.apple.com/documentation/apple_search_ads/delete_ad_group_negative_keywords
 func (s *KeywordService) DeleteAdGroupNegativeKeywords(campaignID int64, adGroupID int64, keywordIds []int64) (*IntegerResponse, error) {
+	return s.DeleteAdGroupNegativeKeywordsWithContext(context.Background(), campaignID, adGroupID, keywordIds)
+}
+
+// DeleteAdGroupNegativeKeywordsWithContext is like DeleteAdGroupNegativeKeywords but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) DeleteAdGroupNegativeKeywordsWithContext(ctx context.Context, campaignID int64, adGroupID int64, keywordIds []int64) (*IntegerResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/adgroups/%d/negativekeywords/delete/bulk", campaignID, adGroupID)
 	res := new(IntegerResponse)
-	err := s.client.post(url, res, keywordIds)
+	err := s.client.post(ctx, url, res, keywordIds)
 
 	return res, err
 }
@@ -287,9 +466,14 @@ func (s *KeywordService) DeleteAdGroupNegativeKeywords(campaignID int64, adGroup
 //
 // https://developer.apple.com/documentation/apple_search_ads/update_campaign_negative_keywords
 func (s *KeywordService) UpdateNegativeKeywords(campaignID int64, updateRequests []*NegativeKeyword) (*NegativeKeywordListResponse, error) {
+	return s.UpdateNegativeKeywordsWithContext(context.Background(), campaignID, updateRequests)
+}
+
+// UpdateNegativeKeywordsWithContext is like UpdateNegativeKeywords but uses ctx to cancel the request or bound its deadline.
+func (s *KeywordService) UpdateNegativeKeywordsWithContext(ctx context.Context, campaignID int64, updateRequests []*NegativeKeyword) (*NegativeKeywordListResponse, error) {
 	url := fmt.Sprintf("campaigns/%d/negativekeywords/bulk", campaignID)
 	res := new(NegativeKeywordListResponse)
-	err := s.client.put(url, res, updateRequests)
+	err := s.client.put(ctx, url, res, updateRequests)
 
 	return res, err
 }
@@ -0,0 +1,56 @@
+package asa
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestInfo 即将发出的请求信息
+//
+// Header 为实际发送的请求头, BeforeRequest 中可以直接修改以注入自定义请求头。
+type RequestInfo struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+	// OrgID 本次请求使用的组织ID
+	OrgID int64
+	// Attempt 第几次尝试, 从1开始, 重试时递增
+	Attempt int
+}
+
+// ResponseInfo 请求结果, 请求失败时 StatusCode 为0且 Err 非空
+type ResponseInfo struct {
+	Request    *RequestInfo
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Latency    time.Duration
+	Err        error
+}
+
+// Middleware 请求中间件, 每次http请求(包括重试)都会经过
+//
+// BeforeRequest 按注册顺序调用, 返回错误时中止本次请求; AfterResponse 按注册的逆序调用。
+type Middleware struct {
+	BeforeRequest func(ctx context.Context, req *RequestInfo) error
+	AfterResponse func(ctx context.Context, res *ResponseInfo)
+}
+
+// Use 追加请求中间件
+func (c *Client) Use(middlewares ...Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// 复制后追加, 不影响共享同一切片的子客户端和进行中的请求
+	mws := make([]Middleware, 0, len(c.middlewares)+len(middlewares))
+	c.middlewares = append(append(mws, c.middlewares...), middlewares...)
+}
+
+// WithMiddleware 设置请求中间件
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) error {
+		c.middlewares = append(c.middlewares, middlewares...)
+		return nil
+	}
+}
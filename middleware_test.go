@@ -0,0 +1,79 @@
+package asa
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -run TestMiddleware
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "audit", r.Header.Get("X-Request-Source"))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	})
+	c.SetRetryPolicy(fastRetryPolicy())
+
+	var order []string
+	var results []*ResponseInfo
+	c.Use(Middleware{
+		BeforeRequest: func(ctx context.Context, req *RequestInfo) error {
+			order = append(order, "before1")
+			req.Header.Set("X-Request-Source", "audit")
+			return nil
+		},
+		AfterResponse: func(ctx context.Context, res *ResponseInfo) {
+			order = append(order, "after1")
+			results = append(results, res)
+		},
+	}, Middleware{
+		BeforeRequest: func(ctx context.Context, req *RequestInfo) error {
+			order = append(order, "before2")
+			return nil
+		},
+		AfterResponse: func(ctx context.Context, res *ResponseInfo) {
+			order = append(order, "after2")
+		},
+	})
+
+	_, err := c.Campaigns.GetCampaign(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"before1", "before2", "after2", "after1", "before1", "before2", "after2", "after1"}, order)
+	assert.Len(t, results, 2)
+	assert.Equal(t, http.StatusServiceUnavailable, results[0].StatusCode)
+	assert.Equal(t, 1, results[0].Request.Attempt)
+	assert.Equal(t, http.StatusOK, results[1].StatusCode)
+	assert.Equal(t, 2, results[1].Request.Attempt)
+	assert.Equal(t, http.MethodGet, results[1].Request.Method)
+	assert.Contains(t, results[1].Request.URL, "/campaigns/1")
+	assert.JSONEq(t, `{"data":{"id":1}}`, string(results[1].Body))
+}
+
+// go test -v -run TestMiddlewareAbort
+func TestMiddlewareAbort(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent")
+	})
+	errBlocked := errors.New("blocked")
+	c.Use(Middleware{
+		BeforeRequest: func(ctx context.Context, req *RequestInfo) error {
+			return errBlocked
+		},
+	})
+
+	_, err := c.Campaigns.DeleteCampaign(1)
+	assert.ErrorIs(t, err, errBlocked)
+}
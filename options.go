@@ -0,0 +1,164 @@
+package asa
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ropon/requests/v2"
+)
+
+// Option 客户端配置项, 配合 New 使用
+type Option func(c *Client) error
+
+// New 使用配置项创建客户端, 配置无效时返回错误
+//
+// 必须通过 WithTokenConfig、WithAccessToken 或 WithTokenSource 指定认证方式; 未指定http.Client时使用默认超时的http.Client。
+// 配置项按顺序生效, 与 NewClient 不同, 创建时不会提前换取access token。
+func New(opts ...Option) (*Client, error) {
+	req := requests.New()
+	SetDefault(req)
+	c := &Client{
+		client:      req,
+		httpClient:  &http.Client{Timeout: defaultTimeout},
+		retryPolicy: DefaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.tokenSource == nil {
+		return nil, errors.New("asa: no auth source, use WithTokenConfig, WithAccessToken or WithTokenSource")
+	}
+	// 组织ID由客户端自身维护, SetOrgID 不修改共享的TokenConfig
+	c.scoped = true
+	if c.orgID == 0 && c.auth != nil {
+		c.orgID = c.auth.currentOrgID()
+	}
+	c.initServices()
+	return c, nil
+}
+
+// WithHTTPClient 使用自定义的http.Client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("asa: http client is nil")
+		}
+		c.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithTransport 使用自定义的http.RoundTripper, 例如代理、链路追踪或测试用的Transport
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) error {
+		if transport == nil {
+			return errors.New("asa: transport is nil")
+		}
+		httpClient := *c.httpClient
+		httpClient.Transport = transport
+		c.httpClient = &httpClient
+		return nil
+	}
+}
+
+// WithTimeout 设置http请求超时时间
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return fmt.Errorf("asa: invalid timeout %s", timeout)
+		}
+		httpClient := *c.httpClient
+		httpClient.Timeout = timeout
+		c.httpClient = &httpClient
+		return nil
+	}
+}
+
+// WithBaseURL 设置接口基础地址, 默认为 https://api.searchads.apple.com/api/v5/
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("asa: invalid base url: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("asa: invalid base url %q", baseURL)
+		}
+		c.baseURL = u
+		return nil
+	}
+}
+
+// WithUserAgent 设置User-Agent请求头
+func WithUserAgent(ua string) Option {
+	return func(c *Client) error {
+		if ua == "" {
+			return errors.New("asa: user agent is empty")
+		}
+		c.userAgent = ua
+		return nil
+	}
+}
+
+// WithTokenConfig 使用 TokenConfig 获取access token
+func WithTokenConfig(auth *TokenConfig) Option {
+	return func(c *Client) error {
+		if auth == nil {
+			return errors.New("asa: token config is nil")
+		}
+		if err := WithTokenSource(auth)(c); err != nil {
+			return err
+		}
+		c.auth = auth
+		return nil
+	}
+}
+
+// WithAccessToken 使用固定的access token
+func WithAccessToken(accessToken string) Option {
+	return func(c *Client) error {
+		if accessToken == "" {
+			return errors.New("asa: access token is empty")
+		}
+		return WithTokenSource(StaticTokenSource(accessToken))(c)
+	}
+}
+
+// WithDefaultOrgID 设置默认组织ID
+func WithDefaultOrgID(orgID int64) Option {
+	return func(c *Client) error {
+		if orgID <= 0 {
+			return fmt.Errorf("asa: invalid org id %d", orgID)
+		}
+		c.orgID = orgID
+		return nil
+	}
+}
+
+// WithRetryPolicy 设置重试策略, 传nil关闭重试
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Client) error {
+		c.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimiter 设置请求限流器
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(c *Client) error {
+		c.rateLimiter = limiter
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package asa
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// roundTripFunc 用函数实现http.RoundTripper
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// go test -v -run TestNewWithOptions
+func TestNewWithOptions(t *testing.T) {
+	t.Parallel()
+
+	var got *http.Request
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		got = req
+		rec := httptest.NewRecorder()
+		_, _ = rec.WriteString(`{"data":{"id":1}}`)
+		return rec.Result(), nil
+	})
+
+	c, err := New(
+		WithTransport(transport),
+		WithBaseURL("http://asa.local/api/v5"),
+		WithUserAgent("my-agent"),
+		WithAccessToken("token"),
+		WithDefaultOrgID(42),
+	)
+	assert.NoError(t, err)
+
+	res, err := c.Campaigns.GetCampaign(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.Campaign.ID)
+	assert.Equal(t, "http://asa.local/api/v5/campaigns/1", got.URL.String())
+	assert.Equal(t, "my-agent", got.Header.Get("User-Agent"))
+	assert.Equal(t, "Bearer token", got.Header.Get("Authorization"))
+	assert.Equal(t, "orgId=42", got.Header.Get("X-AP-Context"))
+}
+
+// go test -v -run TestNewInvalidOptions
+func TestNewInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string][]Option{
+		"no auth":          {WithBaseURL("https://example.com/")},
+		"nil client":       {WithAccessToken("token"), WithHTTPClient(nil)},
+		"nil transport":    {WithAccessToken("token"), WithTransport(nil)},
+		"bad base url":     {WithAccessToken("token"), WithBaseURL("not a url")},
+		"nil config":       {WithTokenConfig(nil)},
+		"empty token":      {WithAccessToken("")},
+		"bad org id":       {WithAccessToken("token"), WithDefaultOrgID(-1)},
+		"negative timeout": {WithAccessToken("token"), WithTimeout(-1)},
+	}
+	for name, opts := range cases {
+		c, err := New(opts...)
+		assert.Error(t, err, name)
+		assert.Nil(t, c, name)
+	}
+}
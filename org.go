@@ -0,0 +1,178 @@
+package asa
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/ropon/requests/v2"
+)
+
+type orgIDKey struct{}
+
+// WithOrgID 指定本次调用使用的组织ID, 优先于客户端上设置的组织ID
+func WithOrgID(ctx context.Context, orgID int64) context.Context {
+	return context.WithValue(ctx, orgIDKey{}, orgID)
+}
+
+// orgIDFromContext 获取ctx中指定的组织ID
+func orgIDFromContext(ctx context.Context) (int64, bool) {
+	orgID, ok := ctx.Value(orgIDKey{}).(int64)
+	return orgID, ok
+}
+
+// ForOrg 创建固定使用orgID的子客户端
+//
+// 子客户端与父客户端共享认证、http.Client、重试策略和限流器, 多个子客户端可以在不同goroutine中
+// 同时操作不同的组织; 子客户端复制父客户端的请求头与debug配置, 在子客户端上调用 SetOrgID、
+// SetHTTPDebug、SetHTTPTimeout、SetHTTPProxy 不会影响父客户端及其它子客户端。
+func (c *Client) ForOrg(orgID int64) *Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	child := &Client{
+		auth:        c.auth,
+		client:      requests.New(),
+		baseURL:     c.baseURL,
+		userAgent:   c.userAgent,
+		tokenSource: c.tokenSource,
+		orgID:       orgID,
+		scoped:      true,
+		retryPolicy: c.retryPolicy,
+		rateLimiter: c.rateLimiter,
+		middlewares: c.middlewares,
+		callHooks:   c.callHooks,
+	}
+	SetDefault(child.client)
+	// 复制父客户端的请求头、debug与http.Client, 子客户端修改配置不影响父客户端
+	if cfg, err := c.requestConfig(); err == nil {
+		child.client.BaseUrl = cfg.baseURL
+		child.client.Debug = cfg.debug
+		_ = setRequestHeader(child.client, cfg.header)
+		httpClient := *cfg.httpClient
+		child.httpClient = &httpClient
+	} else {
+		child.httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	child.initServices()
+	return child
+}
+
+// OrgFilter 筛选需要处理的组织
+type OrgFilter func(acl *UserACL) bool
+
+// HasRole 筛选拥有任一指定角色的组织
+func HasRole(roles ...UserACLRoleName) OrgFilter {
+	return func(acl *UserACL) bool {
+		for _, have := range acl.RoleNames {
+			for _, want := range roles {
+				if have == want {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// HasCurrency 筛选使用任一指定币种的组织
+func HasCurrency(currencies ...string) OrgFilter {
+	return func(acl *UserACL) bool {
+		for _, currency := range currencies {
+			if acl.Currency == currency {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// HasTimeZone 筛选使用任一指定时区的组织
+func HasTimeZone(timeZones ...ReportingRequestTimeZone) OrgFilter {
+	return func(acl *UserACL) bool {
+		for _, timeZone := range timeZones {
+			if acl.TimeZone == timeZone {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchAll 组合多个筛选条件, 全部满足才处理
+func MatchAll(filters ...OrgFilter) OrgFilter {
+	return func(acl *UserACL) bool {
+		for _, filter := range filters {
+			if filter != nil && !filter(acl) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// ForEachOrgOptions ForEachOrg 的可选配置
+type ForEachOrgOptions struct {
+	// Parallelism 同时处理的组织数量, 小于1时为1
+	Parallelism int
+	// Filter 组织筛选条件, 为nil时处理全部组织
+	Filter OrgFilter
+}
+
+// OrgResult 单个组织的处理结果
+type OrgResult[T any] struct {
+	ACL    *UserACL
+	Result T
+	Err    error
+}
+
+// ForEachOrg 获取可访问的全部组织, 按条件筛选后以有限并发对每个组织调用fn
+//
+// fn 收到的客户端已通过 ForOrg 固定为对应组织; 结果按ACL返回顺序排列, 单个组织的错误记录在
+// OrgResult.Err 中, 只有获取ACL失败时才返回error。ctx结束后尚未开始的组织会记录ctx.Err()。
+func ForEachOrg[T any](ctx context.Context, c *Client, opts *ForEachOrgOptions, fn func(ctx context.Context, client *Client, acl *UserACL) (T, error)) ([]*OrgResult[T], error) {
+	if opts == nil {
+		opts = &ForEachOrgOptions{}
+	}
+	res, err := c.AccessControlList.GetUserACLWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		results []*OrgResult[T]
+		seen    = make(map[int64]bool)
+	)
+	for _, acl := range res.UserAcls {
+		if acl == nil || seen[acl.OrgID] || (opts.Filter != nil && !opts.Filter(acl)) {
+			continue
+		}
+		seen[acl.OrgID] = true
+		results = append(results, &OrgResult[T]{ACL: acl})
+	}
+
+	parallelism := opts.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for _, result := range results {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			result.Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(result *OrgResult[T]) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			result.Result, result.Err = fn(ctx, c.ForOrg(result.ACL.OrgID), result.ACL)
+		}(result)
+	}
+	wg.Wait()
+	return results, nil
+}
@@ -0,0 +1,126 @@
+package asa
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -race -run TestForOrg
+func TestForOrg(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		orgID := strings.TrimPrefix(r.Header.Get("X-AP-Context"), "orgId=")
+		_, _ = w.Write([]byte(`{"data":{"id":` + orgID + `}}`))
+	})
+	assert.NoError(t, c.SetOrgID(1))
+
+	var wg sync.WaitGroup
+	for i := int64(2); i < 20; i++ {
+		wg.Add(1)
+		go func(orgID int64) {
+			defer wg.Done()
+			res, err := c.ForOrg(orgID).Campaigns.GetCampaign(1)
+			assert.NoError(t, err)
+			assert.Equal(t, orgID, res.Campaign.ID)
+		}(i)
+	}
+	wg.Wait()
+
+	child := c.ForOrg(5)
+	assert.NoError(t, child.SetOrgID(6))
+	res, err := child.Campaigns.GetCampaign(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), res.Campaign.ID)
+
+	res, err = c.Campaigns.GetCampaignWithContext(WithOrgID(context.Background(), 7), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), res.Campaign.ID)
+
+	res, err = c.Campaigns.GetCampaign(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.Campaign.ID)
+
+	// 子客户端的debug与超时配置不影响父客户端
+	parent, err := c.requestConfig()
+	assert.NoError(t, err)
+	timeout := parent.httpClient.Timeout
+	assert.NoError(t, child.SetHTTPDebug(true))
+	assert.NoError(t, child.SetHTTPTimeout(time.Second))
+	assert.False(t, c.client.Debug)
+	assert.False(t, c.ForOrg(8).client.Debug)
+	parent, err = c.requestConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, timeout, parent.httpClient.Timeout)
+	assert.Equal(t, time.Second, child.httpClient.Timeout)
+	assert.Equal(t, c.client.BaseUrl, child.client.BaseUrl)
+
+	// SetHeader 设置的请求头复制到子客户端, 子客户端修改请求头不影响父客户端
+	c.client.SetHeader("X-Custom", "parent")
+	child = c.ForOrg(9)
+	header, err := requestHeader(child.client)
+	assert.NoError(t, err)
+	assert.Equal(t, "parent", header.Get("X-Custom"))
+	child.client.SetHeader("X-Custom", "child")
+	header, err = requestHeader(c.client)
+	assert.NoError(t, err)
+	assert.Equal(t, "parent", header.Get("X-Custom"))
+}
+
+// go test -v -race -run TestForEachOrg
+func TestForEachOrg(t *testing.T) {
+	t.Parallel()
+
+	var running, maxRunning int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/acls" {
+			_, _ = w.Write([]byte(`{"data":[
+				{"orgId":1,"currency":"USD","roleNames":["API Account Manager"]},
+				{"orgId":2,"currency":"EUR","roleNames":["API Account Manager"]},
+				{"orgId":3,"currency":"USD","roleNames":["API Account Read Only"]},
+				{"orgId":4,"currency":"USD","roleNames":["API Account Manager"]},
+				{"orgId":5,"currency":"USD","roleNames":["API Account Manager"]}
+			]}`))
+			return
+		}
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		orgID := strings.TrimPrefix(r.Header.Get("X-AP-Context"), "orgId=")
+		if orgID == "4" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":` + orgID + `}}`))
+	})
+
+	results, err := ForEachOrg(context.Background(), c, &ForEachOrgOptions{
+		Parallelism: 2,
+		Filter:      MatchAll(HasRole(UserACLRoleNameAPIAccountManager), HasCurrency("USD")),
+	}, func(ctx context.Context, client *Client, acl *UserACL) (int64, error) {
+		res, err := client.Campaigns.GetCampaignWithContext(ctx, 1)
+		if err != nil {
+			return 0, err
+		}
+		return res.Campaign.ID, nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, int64(1), results[0].Result)
+	assert.True(t, IsNotFound(results[1].Err))
+	assert.Equal(t, int64(5), results[2].Result)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(2))
+}
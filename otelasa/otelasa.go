@@ -0,0 +1,218 @@
+// Package otelasa 为 asa.Client 提供OpenTelemetry链路追踪与监控指标
+//
+// 每次接口调用生成一个span(包含重试), 并记录调用耗时、错误、重试与限流指标;
+// access token换取同样会生成span。
+//
+// otelasa 是独立的module, 使用 asa 而不需要监控时不会引入OpenTelemetry依赖:
+//
+//	go get github.com/ropon/apple-search-ads-go/v2/otelasa
+//
+//	hook, err := otelasa.NewCallHook()
+//	client.UseCallHook(hook)
+//	tokenConfig.Use(otelasa.NewTokenHook())
+package otelasa
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	asa "github.com/ropon/apple-search-ads-go/v2"
+)
+
+// ScopeName 指标与span使用的instrumentation scope
+const ScopeName = "github.com/ropon/apple-search-ads-go/v2/otelasa"
+
+// 属性名
+const (
+	AttrService    = attribute.Key("asa.service")
+	AttrOperation  = attribute.Key("asa.operation")
+	AttrOrgID      = attribute.Key("asa.org_id")
+	AttrAttempts   = attribute.Key("asa.attempts")
+	AttrRetries    = attribute.Key("asa.retries")
+	AttrClientID   = attribute.Key("asa.client_id")
+	AttrMethod     = attribute.Key("http.request.method")
+	AttrStatusCode = attribute.Key("http.response.status_code")
+	AttrURLPath    = attribute.Key("url.path")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option 配置项
+type Option func(cfg *config)
+
+// WithTracerProvider 使用指定的TracerProvider, 默认使用全局TracerProvider
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(cfg *config) {
+		cfg.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider 使用指定的MeterProvider, 默认使用全局MeterProvider
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(cfg *config) {
+		cfg.meterProvider = mp
+	}
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// instruments 接口调用指标
+type instruments struct {
+	duration    metric.Float64Histogram
+	errors      metric.Int64Counter
+	retries     metric.Int64Counter
+	rateLimited metric.Int64Counter
+}
+
+func newInstruments(meter metric.Meter) (*instruments, error) {
+	var (
+		ins instruments
+		err error
+	)
+	ins.duration, err = meter.Float64Histogram("asa.client.call.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of Apple Search Ads API calls, including retries."))
+	if err != nil {
+		return nil, err
+	}
+	ins.errors, err = meter.Int64Counter("asa.client.call.errors",
+		metric.WithUnit("{call}"),
+		metric.WithDescription("Number of Apple Search Ads API calls that returned an error."))
+	if err != nil {
+		return nil, err
+	}
+	ins.retries, err = meter.Int64Counter("asa.client.call.retries",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Number of retried Apple Search Ads API requests."))
+	if err != nil {
+		return nil, err
+	}
+	ins.rateLimited, err = meter.Int64Counter("asa.client.rate_limited",
+		metric.WithUnit("{response}"),
+		metric.WithDescription("Number of HTTP 429 responses from the Apple Search Ads API."))
+	if err != nil {
+		return nil, err
+	}
+	return &ins, nil
+}
+
+// NewCallHook 创建接口调用回调, 通过 asa.Client.UseCallHook 或 asa.WithCallHook 注册
+func NewCallHook(opts ...Option) (asa.CallHook, error) {
+	cfg := newConfig(opts)
+	tracer := cfg.tracerProvider.Tracer(ScopeName)
+	ins, err := newInstruments(cfg.meterProvider.Meter(ScopeName))
+	if err != nil {
+		return asa.CallHook{}, err
+	}
+
+	return asa.CallHook{
+		BeforeCall: func(ctx context.Context, info *asa.CallInfo) context.Context {
+			name := info.Operation
+			if name == "" {
+				name = "asa " + info.Method
+			}
+			ctx, _ = tracer.Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					AttrService.String(info.Service),
+					AttrOperation.String(info.Operation),
+					AttrOrgID.Int64(info.OrgID),
+					AttrMethod.String(info.Method),
+					AttrURLPath.String(info.URL),
+				))
+			return ctx
+		},
+		AfterCall: func(ctx context.Context, info *asa.CallInfo) {
+			retries := info.Attempts - 1
+			if retries < 0 {
+				retries = 0
+			}
+
+			span := trace.SpanFromContext(ctx)
+			span.SetAttributes(
+				AttrAttempts.Int(info.Attempts),
+				AttrRetries.Int(retries),
+			)
+			if info.StatusCode != 0 {
+				span.SetAttributes(AttrStatusCode.Int(info.StatusCode))
+			}
+			if info.Err != nil {
+				span.RecordError(info.Err)
+				span.SetStatus(codes.Error, info.Err.Error())
+			}
+			span.End()
+
+			attrs := metric.WithAttributes(
+				AttrOperation.String(info.Operation),
+				AttrMethod.String(info.Method),
+				AttrStatusCode.Int(info.StatusCode),
+			)
+			ins.duration.Record(ctx, info.Latency.Seconds(), attrs)
+			if info.Err != nil {
+				ins.errors.Add(ctx, 1, attrs)
+			}
+			if retries > 0 {
+				ins.retries.Add(ctx, int64(retries), attrs)
+			}
+			if info.RateLimited > 0 {
+				ins.rateLimited.Add(ctx, int64(info.RateLimited), attrs)
+			}
+		},
+	}, nil
+}
+
+// NewTokenHook 创建access token换取回调, 通过 asa.TokenConfig.Use 注册
+func NewTokenHook(opts ...Option) asa.TokenHook {
+	tracer := newConfig(opts).tracerProvider.Tracer(ScopeName)
+	return asa.TokenHook{
+		BeforeExchange: func(ctx context.Context, info *asa.TokenExchangeInfo) context.Context {
+			ctx, _ = tracer.Start(ctx, "TokenConfig.AccessToken",
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					AttrClientID.String(info.ClientID),
+					AttrMethod.String(http.MethodPost),
+				))
+			return ctx
+		},
+		AfterExchange: func(ctx context.Context, info *asa.TokenExchangeInfo) {
+			span := trace.SpanFromContext(ctx)
+			if info.StatusCode != 0 {
+				span.SetAttributes(AttrStatusCode.Int(info.StatusCode))
+			}
+			if info.Err != nil {
+				span.RecordError(info.Err)
+				span.SetStatus(codes.Error, info.Err.Error())
+			}
+			span.End()
+		},
+	}
+}
+
+// WithInstrumentation 返回 asa.New 的配置项, 为客户端注册链路追踪与监控指标
+func WithInstrumentation(opts ...Option) asa.Option {
+	return func(c *asa.Client) error {
+		hook, err := NewCallHook(opts...)
+		if err != nil {
+			return err
+		}
+		return asa.WithCallHook(hook)(c)
+	}
+}
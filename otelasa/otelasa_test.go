@@ -0,0 +1,159 @@
+package otelasa
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	asa "github.com/ropon/apple-search-ads-go/v2"
+)
+
+func newTestProviders() (*tracetest.InMemoryExporter, *sdktrace.TracerProvider, *sdkmetric.ManualReader, *sdkmetric.MeterProvider) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	return exporter, tp, reader, mp
+}
+
+func spanAttr(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func sumValue(rm metricdata.ResourceMetrics, name string) int64 {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			var total int64
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				total += dp.Value
+			}
+			return total
+		}
+	}
+	return 0
+}
+
+// go test -v -run TestCallHook
+func TestCallHook(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	exporter, tp, reader, mp := newTestProviders()
+	c, err := asa.New(
+		asa.WithAccessToken("token"),
+		asa.WithDefaultOrgID(42),
+		asa.WithBaseURL(srv.URL),
+		asa.WithRetryPolicy(&asa.RetryPolicy{
+			MaxAttempts:          3,
+			InitialInterval:      time.Millisecond,
+			MaxInterval:          time.Millisecond,
+			RetryableStatusCodes: []int{http.StatusTooManyRequests},
+		}),
+		WithInstrumentation(WithTracerProvider(tp), WithMeterProvider(mp)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Campaigns.GetCampaign(1)
+	assert.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	if !assert.Len(t, spans, 1) {
+		return
+	}
+	span := spans[0]
+	assert.Equal(t, "CampaignService.GetCampaign", span.Name)
+	assert.Equal(t, "CampaignService", spanAttr(span, AttrService).AsString())
+	assert.Equal(t, int64(42), spanAttr(span, AttrOrgID).AsInt64())
+	assert.Equal(t, int64(http.StatusOK), spanAttr(span, AttrStatusCode).AsInt64())
+	assert.Equal(t, int64(1), spanAttr(span, AttrRetries).AsInt64())
+	assert.Equal(t, codes.Unset, span.Status.Code)
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	assert.Equal(t, int64(1), sumValue(rm, "asa.client.call.retries"))
+	assert.Equal(t, int64(1), sumValue(rm, "asa.client.rate_limited"))
+	assert.Equal(t, int64(0), sumValue(rm, "asa.client.call.errors"))
+}
+
+// go test -v -run TestTokenHook
+func TestTokenHook(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth, err := asa.NewTokenConfig("SEARCHADS.test", "SEARCHADS.test", "key",
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exporter, tp, reader, mp := newTestProviders()
+	auth.Use(NewTokenHook(WithTracerProvider(tp)))
+	c, err := asa.New(asa.WithTokenConfig(auth), WithInstrumentation(WithTracerProvider(tp), WithMeterProvider(mp)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ctx已取消, 换取token失败且不会发出请求
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.Campaigns.GetCampaignWithContext(ctx, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	spans := exporter.GetSpans()
+	if !assert.Len(t, spans, 2) {
+		return
+	}
+	token, call := spans[0], spans[1]
+	assert.Equal(t, "TokenConfig.AccessToken", token.Name)
+	assert.Equal(t, "SEARCHADS.test", spanAttr(token, AttrClientID).AsString())
+	assert.Equal(t, codes.Error, token.Status.Code)
+	assert.Equal(t, call.SpanContext.SpanID(), token.Parent.SpanID())
+	assert.Equal(t, "CampaignService.GetCampaign", call.Name)
+	assert.Equal(t, codes.Error, call.Status.Code)
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	assert.Equal(t, int64(1), sumValue(rm, "asa.client.call.errors"))
+}
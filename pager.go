@@ -0,0 +1,237 @@
+package asa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// defaultPageSize 自动翻页时每页的记录数, 为接口允许的最大值
+const defaultPageSize = 1000
+
+// ErrStopIteration 在回调中返回以提前结束遍历, ForEach 等方法不会将其作为错误返回
+var ErrStopIteration = errors.New("asa: stop iteration")
+
+// PageFunc 获取从offset开始的一页数据, 返回本页记录与分页信息
+type PageFunc[T any] func(ctx context.Context, offset, limit int) ([]T, *PageDetail, error)
+
+// Pager 按Limit/Offset自动翻页的迭代器, 可以重复遍历
+type Pager[T any] struct {
+	fetch    PageFunc[T]
+	offset   int
+	pageSize int
+}
+
+// NewPager 使用fetch创建迭代器
+func NewPager[T any](fetch PageFunc[T]) *Pager[T] {
+	return &Pager[T]{fetch: fetch, pageSize: defaultPageSize}
+}
+
+// PageSize 设置每页记录数, 小于等于0时使用默认值1000
+func (p *Pager[T]) PageSize(n int) *Pager[T] {
+	if n <= 0 {
+		n = defaultPageSize
+	}
+	p.pageSize = n
+	return p
+}
+
+// Offset 设置起始位置
+func (p *Pager[T]) Offset(n int) *Pager[T] {
+	if n < 0 {
+		n = 0
+	}
+	p.offset = n
+	return p
+}
+
+// ForEachPage 依次获取每一页并调用fn, fn返回错误时停止
+//
+// 已获取的记录数达到 PageDetail.TotalResults、返回空页或返回的记录数少于每页记录数时结束;
+// 获取某一页失败时返回错误, 之前的页已经交给fn处理。
+func (p *Pager[T]) ForEachPage(ctx context.Context, fn func(items []T, page *PageDetail) error) error {
+	offset := p.offset
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		items, page, err := p.fetch(ctx, offset, p.pageSize)
+		if err != nil {
+			return fmt.Errorf("asa: fetch page at offset %d: %w", offset, err)
+		}
+		if len(items) > 0 {
+			if err := fn(items, page); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+		offset += len(items)
+		if !hasNextPage(page, offset, len(items), p.pageSize) {
+			return nil
+		}
+	}
+}
+
+// hasNextPage 判断是否还有下一页
+func hasNextPage(page *PageDetail, offset, n, pageSize int) bool {
+	if n == 0 {
+		return false
+	}
+	if page != nil && page.TotalResults > 0 {
+		return offset < page.TotalResults
+	}
+	return n >= pageSize
+}
+
+// ForEach 依次对每条记录调用fn, fn返回错误时停止, 返回 ErrStopIteration 时正常结束
+func (p *Pager[T]) ForEach(ctx context.Context, fn func(item T) error) error {
+	return p.ForEachPage(ctx, func(items []T, page *PageDetail) error {
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// All 获取所有记录, 出错时同时返回已获取的记录
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	err := p.ForEachPage(ctx, func(items []T, page *PageDetail) error {
+		all = append(all, items...)
+		return nil
+	})
+	return all, err
+}
+
+// pageSelector 复制selector并设置分页参数, 不修改调用方的selector
+func pageSelector(selector *Selector, offset, limit int) *Selector {
+	s := &Selector{}
+	if selector != nil {
+		*s = *selector
+	}
+	s.Pagination = &Pagination{Offset: uint32(offset), Limit: uint32(limit)}
+	return s
+}
+
+// newSelectorPager 创建按 Selector.Pagination 翻页的迭代器, 起始位置与每页记录数默认取自selector
+func newSelectorPager[T any](selector *Selector, fetch PageFunc[T]) *Pager[T] {
+	p := NewPager(fetch)
+	if selector != nil && selector.Pagination != nil {
+		p.Offset(int(selector.Pagination.Offset)).PageSize(int(selector.Pagination.Limit))
+	}
+	return p
+}
+
+// AllParallel 获取所有记录, 根据第一页返回的 PageDetail.TotalResults 并发获取剩余的页
+//
+// workers 为并发数, 小于等于1时等价于 All; 请求同样经过客户端的限流器与重试策略。
+// 结果按offset顺序返回, 与 All 一致; 某一页失败时不再获取之后的页, 返回失败页之前的连续记录与错误。
+// 中间某一页返回的记录数与每页记录数不一致时(例如遍历期间有记录被删除), 从该页之后改为按顺序翻页,
+// 避免遗漏或重复记录。接口未返回 TotalResults 时按顺序翻页。
+func (p *Pager[T]) AllParallel(ctx context.Context, workers int) ([]T, error) {
+	if workers <= 1 {
+		return p.All(ctx)
+	}
+	first, page, err := p.fetch(ctx, p.offset, p.pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("asa: fetch page at offset %d: %w", p.offset, err)
+	}
+	offset := p.offset + len(first)
+	if !hasNextPage(page, offset, len(first), p.pageSize) {
+		return first, nil
+	}
+	if page == nil || page.TotalResults <= 0 {
+		// 无法得知总数, 剩余的页只能按顺序获取
+		return p.appendSequential(ctx, first, offset)
+	}
+
+	// 接口可能返回少于请求数量的记录, 按实际的每页记录数计算剩余的页
+	step := p.pageSize
+	if len(first) < step {
+		step = len(first)
+	}
+	var offsets []int
+	for off := offset; off < page.TotalResults; off += step {
+		offsets = append(offsets, off)
+	}
+
+	var (
+		pages   = make([][]T, len(offsets))
+		errs    = make([]error, len(offsets))
+		fetched = make([]bool, len(offsets))
+		next    = make(chan int)
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		// stop 最早失败或记录数不一致的页, 之后的页不再获取
+		stop = len(offsets)
+	)
+	last := len(offsets) - 1
+	stopAt := func(i int) {
+		mu.Lock()
+		defer mu.Unlock()
+		if i < stop {
+			stop = i
+		}
+	}
+	stopped := func(i int) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return i > stop
+	}
+	if workers > len(offsets) {
+		workers = len(offsets)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if ctx.Err() != nil || stopped(i) {
+					continue
+				}
+				items, _, err := p.fetch(ctx, offsets[i], step)
+				if err != nil {
+					errs[i] = fmt.Errorf("asa: fetch page at offset %d: %w", offsets[i], err)
+					stopAt(i)
+					continue
+				}
+				pages[i], fetched[i] = items, true
+				if i != last && len(items) != step {
+					stopAt(i)
+				}
+			}
+		}()
+	}
+	for i := range offsets {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	all := first
+	for i := range offsets {
+		if errs[i] != nil {
+			return all, errs[i]
+		}
+		if !fetched[i] {
+			return all, ctx.Err()
+		}
+		all = append(all, pages[i]...)
+		if i != last && len(pages[i]) != step {
+			// 记录在遍历期间发生变化, 之后的页按实际位置顺序获取
+			return p.appendSequential(ctx, all, offsets[i]+len(pages[i]))
+		}
+	}
+	return all, nil
+}
+
+// appendSequential 从offset开始按顺序获取剩余的页并追加到items
+func (p *Pager[T]) appendSequential(ctx context.Context, items []T, offset int) ([]T, error) {
+	rest, err := (&Pager[T]{fetch: p.fetch, offset: offset, pageSize: p.pageSize}).All(ctx)
+	return append(items, rest...), err
+}
@@ -0,0 +1,31 @@
+//go:build go1.23
+
+package asa
+
+import (
+	"context"
+	"iter"
+)
+
+// Seq 返回可用于 for range 的迭代器, 出错时最后产出一次 (零值, err) 并结束
+//
+//	for campaign, err := range client.Campaigns.GetAllCampaignsPager().Seq(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (p *Pager[T]) Seq(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := p.ForEach(ctx, func(item T) error {
+			if !yield(item, nil) {
+				return ErrStopIteration
+			}
+			return nil
+		})
+		if err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+//go:build go1.23
+
+package asa
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -run TestPagerSeq
+func TestPagerSeq(t *testing.T) {
+	t.Parallel()
+
+	var offsets []int
+	c := newTestClient(t, campaignPageHandler(t, 25, 20, &offsets))
+	c.SetRetryPolicy(nil)
+
+	var (
+		ids  []int64
+		errs []error
+	)
+	for campaign, err := range c.Campaigns.GetAllCampaignsPager().PageSize(10).Seq(context.Background()) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, campaign.ID)
+	}
+	assert.Len(t, ids, 20)
+	var apiErr *APIError
+	if assert.Len(t, errs, 1) && assert.ErrorAs(t, errs[0], &apiErr) {
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	}
+
+	// break 后不再请求下一页
+	offsets = nil
+	for campaign := range c.Campaigns.GetAllCampaignsPager().PageSize(10).Seq(context.Background()) {
+		if campaign.ID == 5 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0}, offsets)
+}
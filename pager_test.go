@@ -0,0 +1,178 @@
+package asa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// campaignPageHandler 模拟分页接口, 共total条记录, failAt为返回500的offset
+func campaignPageHandler(t *testing.T, total, failAt int, offsets *[]int) http.HandlerFunc {
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) {
+		var offset, limit int
+		if r.Method == http.MethodPost {
+			var selector Selector
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&selector))
+			offset, limit = int(selector.Pagination.Offset), int(selector.Pagination.Limit)
+		} else {
+			offset, _ = strconv.Atoi(r.URL.Query().Get("offset"))
+			limit, _ = strconv.Atoi(r.URL.Query().Get("limit"))
+		}
+		mu.Lock()
+		*offsets = append(*offsets, offset)
+		mu.Unlock()
+		if offset == failAt {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var items []string
+		for i := offset; i < total && i < offset+limit; i++ {
+			items = append(items, fmt.Sprintf(`{"id":%d}`, i+1))
+		}
+		_, _ = fmt.Fprintf(w, `{"data":[%s],"pagination":{"totalResults":%d,"startIndex":%d,"itemsPerPage":%d}}`,
+			strings.Join(items, ","), total, offset, len(items))
+	}
+}
+
+// go test -v -run TestPagerAll
+func TestPagerAll(t *testing.T) {
+	t.Parallel()
+
+	var offsets []int
+	c := newTestClient(t, campaignPageHandler(t, 25, -1, &offsets))
+
+	campaigns, err := c.Campaigns.GetAllCampaignsPager().PageSize(10).All(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, campaigns, 25)
+	assert.Equal(t, int64(25), campaigns[24].ID)
+	assert.Equal(t, []int{0, 10, 20}, offsets)
+
+	// 总数恰好为每页记录数的整数倍时不会多请求一页
+	offsets = nil
+	campaigns, err = c.Campaigns.GetAllCampaignsPager().PageSize(5).Offset(15).All(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, campaigns, 10)
+	assert.Equal(t, []int{15, 20}, offsets)
+}
+
+// go test -v -run TestPagerStopAndError
+func TestPagerStopAndError(t *testing.T) {
+	t.Parallel()
+
+	var offsets []int
+	c := newTestClient(t, campaignPageHandler(t, 25, 10, &offsets))
+	c.SetRetryPolicy(nil)
+
+	var ids []int64
+	err := c.Campaigns.GetAllCampaignsPager().PageSize(10).ForEach(context.Background(), func(item *Campaign) error {
+		ids = append(ids, item.ID)
+		if len(ids) == 3 {
+			return ErrStopIteration
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, ids)
+
+	errCallback := errors.New("callback failed")
+	err = c.Campaigns.GetAllCampaignsPager().ForEach(context.Background(), func(item *Campaign) error {
+		return errCallback
+	})
+	assert.ErrorIs(t, err, errCallback)
+
+	campaigns, err := c.Campaigns.GetAllCampaignsPager().PageSize(10).All(context.Background())
+	assert.Len(t, campaigns, 10)
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	assert.Contains(t, err.Error(), "offset 10")
+}
+
+// go test -v -race -run TestPagerAllParallel
+func TestPagerAllParallel(t *testing.T) {
+	t.Parallel()
+
+	var offsets []int
+	c := newTestClient(t, campaignPageHandler(t, 25, -1, &offsets))
+
+	campaigns, err := c.Campaigns.GetAllCampaignsPager().PageSize(5).AllParallel(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Len(t, campaigns, 25)
+	for i, campaign := range campaigns {
+		assert.Equal(t, int64(i+1), campaign.ID)
+	}
+	sort.Ints(offsets)
+	assert.Equal(t, []int{0, 5, 10, 15, 20}, offsets)
+
+	// 失败时返回失败页之前的连续记录
+	var failOffsets []int
+	c = newTestClient(t, campaignPageHandler(t, 25, 10, &failOffsets))
+	c.SetRetryPolicy(nil)
+	campaigns, err = c.Campaigns.GetAllCampaignsPager().PageSize(5).AllParallel(context.Background(), 2)
+	assert.Len(t, campaigns, 10)
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Contains(t, err.Error(), "offset 10")
+
+	// 未返回总数时按顺序翻页
+	offsets = nil
+	pager := NewPager(func(ctx context.Context, offset, limit int) ([]int, *PageDetail, error) {
+		offsets = append(offsets, offset)
+		var items []int
+		for i := offset; i < 12 && i < offset+limit; i++ {
+			items = append(items, i)
+		}
+		return items, nil, nil
+	})
+	items, err := pager.PageSize(5).AllParallel(context.Background(), 4)
+	assert.NoError(t, err)
+	assert.Len(t, items, 12)
+	assert.Equal(t, []int{0, 5, 10}, offsets)
+
+	// 中间某一页记录数不足时, 之后按顺序翻页, 不遗漏记录
+	var mu sync.Mutex
+	offsets = nil
+	pager = NewPager(func(ctx context.Context, offset, limit int) ([]int, *PageDetail, error) {
+		mu.Lock()
+		offsets = append(offsets, offset)
+		mu.Unlock()
+		end := offset + limit
+		if offset == 4 {
+			end = offset + 3
+		}
+		var items []int
+		for i := offset; i < 12 && i < end; i++ {
+			items = append(items, i)
+		}
+		return items, &PageDetail{TotalResults: 12, StartIndex: offset, ItemsPerPage: len(items)}, nil
+	})
+	items, err = pager.PageSize(4).AllParallel(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, items)
+	assert.Contains(t, offsets, 7)
+}
+
+// go test -v -run TestSelectorPager
+func TestSelectorPager(t *testing.T) {
+	t.Parallel()
+
+	var offsets []int
+	c := newTestClient(t, campaignPageHandler(t, 7, -1, &offsets))
+
+	selector := &Selector{Pagination: &Pagination{Offset: 2, Limit: 3}}
+	campaigns, err := c.Campaigns.FindCampaignsPager(selector).All(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, campaigns, 5)
+	assert.Equal(t, []int{2, 5}, offsets)
+	assert.Equal(t, &Pagination{Offset: 2, Limit: 3}, selector.Pagination)
+}
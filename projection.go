@@ -0,0 +1,162 @@
+package asa
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Projected 只包含部分字段的记录, 同时记录服务端实际返回了哪些字段
+//
+// 通过 Selector.Fields 只请求部分字段时, 未返回的字段在 Value 中为零值, 无法与真实的零值
+// (例如 Deleted=false) 区分。使用 Has 或 Require 判断字段是否存在, 序列化时也只输出存在的字段,
+// 可以安全地作为更新请求的基础。
+type Projected[T any] struct {
+	// Value 解码后的记录
+	Value T
+	// present 服务端返回的字段(json名称)
+	present map[string]bool
+}
+
+// NewProjected 使用value与字段名创建 Projected, fields为空时视为所有字段都存在
+func NewProjected[T any](value T, fields ...string) *Projected[T] {
+	p := &Projected[T]{Value: value}
+	if len(fields) == 0 {
+		fields = jsonFieldNames(reflect.TypeOf(value))
+	}
+	p.present = make(map[string]bool, len(fields))
+	for _, field := range fields {
+		p.present[field] = true
+	}
+	return p
+}
+
+// jsonFieldNames 结构体(或结构体指针)所有字段的json名称
+func jsonFieldNames(typ reflect.Type) []string {
+	if typ == nil {
+		return nil
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	var names []string
+	for i := 0; i < typ.NumField(); i++ {
+		if name := jsonFieldName(typ.Field(i)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Has 判断字段是否由服务端返回, field为json名称, 返回null的字段也视为存在
+func (p *Projected[T]) Has(field string) bool {
+	return p.present[field]
+}
+
+// Fields 返回存在的字段名, 按字母排序
+func (p *Projected[T]) Fields() []string {
+	fields := make([]string, 0, len(p.present))
+	for field := range p.present {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Missing 返回fields中不存在的字段
+func (p *Projected[T]) Missing(fields ...string) []string {
+	var missing []string
+	for _, field := range fields {
+		if !p.present[field] {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+// Require 要求fields都存在, 否则返回列出缺失字段的错误
+func (p *Projected[T]) Require(fields ...string) error {
+	if missing := p.Missing(fields...); len(missing) > 0 {
+		return fmt.Errorf("asa: projected %T is missing fields: %s", p.Value, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// UnmarshalJSON 解码记录并记录出现的字段
+func (p *Projected[T]) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	p.Value = value
+	p.present = make(map[string]bool, len(raw))
+	for field := range raw {
+		p.present[field] = true
+	}
+	return nil
+}
+
+// MarshalJSON 只输出存在的字段, 零值字段也会输出
+func (p Projected[T]) MarshalJSON() ([]byte, error) {
+	v := reflect.ValueOf(p.Value)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return []byte("null"), nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return json.Marshal(p.Value)
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	n := 0
+	for i := 0; i < v.NumField(); i++ {
+		name := jsonFieldName(v.Type().Field(i))
+		if name == "" || !p.present[name] {
+			continue
+		}
+		content, err := json.Marshal(v.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(content)
+		n++
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// ProjectedListResponse 只请求部分字段时的查询结果, 由各 Find*Projected 方法返回
+//
+// 每条记录为 Projected, 可以区分服务端未返回的字段与值为零值的字段。
+type ProjectedListResponse[T any] struct {
+	Data       []*Projected[T]    `json:"data,omitempty"`
+	Error      *ErrorResponseBody `json:"error,omitempty"`
+	Pagination *PageDetail        `json:"pagination,omitempty"`
+}
+
+// Values 返回所有记录的 Value
+func (r *ProjectedListResponse[T]) Values() []T {
+	values := make([]T, 0, len(r.Data))
+	for _, item := range r.Data {
+		values = append(values, item.Value)
+	}
+	return values
+}
@@ -0,0 +1,64 @@
+package asa
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -run TestFindCampaignsProjected
+func TestFindCampaignsProjected(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/campaigns/find", r.URL.Path)
+		var selector Selector
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&selector))
+		assert.Equal(t, []string{"id", "name", "deleted"}, selector.Fields)
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":1,"name":"a","deleted":false},
+			{"id":2,"name":"b","deleted":true,"endTime":null}
+		],"pagination":{"totalResults":2,"startIndex":0,"itemsPerPage":2}}`))
+	})
+
+	selector := NewCampaignSelector().Fields("id", "name", "deleted").MustBuild()
+	res, err := c.Campaigns.FindCampaignsProjected(selector)
+	assert.NoError(t, err)
+	assert.Len(t, res.Data, 2)
+	assert.Equal(t, 2, res.Pagination.TotalResults)
+
+	first := res.Data[0]
+	assert.Equal(t, int64(1), first.Value.ID)
+	assert.True(t, first.Has("deleted"))
+	assert.False(t, first.Has("status"))
+	assert.False(t, first.Has("endTime"))
+	assert.True(t, res.Data[1].Has("endTime"))
+	assert.Equal(t, []string{"deleted", "id", "name"}, first.Fields())
+	assert.NoError(t, first.Require("id", "deleted"))
+	assert.EqualError(t, first.Require("id", "status", "modificationTime"),
+		"asa: projected asa.Campaign is missing fields: status, modificationTime")
+
+	// 只输出存在的字段, 包括零值
+	first.Value.Name = "renamed"
+	content, err := json.Marshal(first)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"name":"renamed","deleted":false}`, string(content))
+
+	assert.Equal(t, []int64{1, 2}, []int64{res.Values()[0].ID, res.Values()[1].ID})
+}
+
+// go test -v -run TestNewProjected
+func TestNewProjected(t *testing.T) {
+	t.Parallel()
+
+	p := NewProjected(Keyword{Text: "shoes", Status: KeywordStatusActive}, "text", "status")
+	content, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"text":"shoes","status":"ACTIVE"}`, string(content))
+
+	all := NewProjected(&NegativeKeyword{ID: 1})
+	assert.True(t, all.Has("matchType"))
+	assert.Empty(t, all.Missing(SelectorFields(SelectorResourceNegativeKeyword)...))
+}
@@ -0,0 +1,515 @@
+package asa
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// 查询语句语法(关键字不区分大小写):
+//
+//	query     = [ condition { "and" condition } ] { clause }
+//	condition = field operator values
+//	clause    = "fields" field { "," field }
+//	          | "order" "by" field [ "asc" | "desc" ] { "," field [ "asc" | "desc" ] }
+//	          | "limit" number
+//	          | "offset" number
+//	values    = value | value "and" value (仅 between) | "(" value { "," value } ")"
+//	value     = word | "双引号字符串"
+//
+// 操作符与 ConditionOperator 的对应关系见 queryOperators, 例如:
+//
+//	status = ENABLED and name startswith "US_" order by modificationTime desc limit 100
+
+// queryOperators 查询语句中的操作符
+var queryOperators = map[string]ConditionOperator{
+	"=":            ConditionOperatorEquals,
+	"==":           ConditionOperatorEquals,
+	"equals":       ConditionOperatorEquals,
+	"!=":           ConditionOperatorNotEqual,
+	"<>":           ConditionOperatorNotEqual,
+	"not_equals":   ConditionOperatorNotEqual,
+	">":            ConditionOperatorGreaterThan,
+	"greater_than": ConditionOperatorGreaterThan,
+	"<":            ConditionOperatorLessThan,
+	"less_than":    ConditionOperatorLessThan,
+	"in":           ConditionOperatorIn,
+	"between":      ConditionOperatorBetween,
+	"contains":     ConditionOperatorContains,
+	"contains_all": ConditionOperatorContainsAll,
+	"contains_any": ConditionOperatorContainsAny,
+	"startswith":   ConditionOperatorStartsWith,
+	"endswith":     ConditionOperatorEndsWith,
+	"like":         ConditionOperatorLike,
+	"is":           ConditionOperatorIs,
+}
+
+// formatOperators 格式化时使用的操作符
+var formatOperators = map[ConditionOperator]string{
+	ConditionOperatorEquals:      "=",
+	ConditionOperatorNotEqual:    "!=",
+	ConditionOperatorGreaterThan: ">",
+	ConditionOperatorLessThan:    "<",
+	ConditionOperatorIn:          "in",
+	ConditionOperatorBetween:     "between",
+	ConditionOperatorContains:    "contains",
+	ConditionOperatorContainsAll: "contains_all",
+	ConditionOperatorContainsAny: "contains_any",
+	ConditionOperatorStartsWith:  "startswith",
+	ConditionOperatorEndsWith:    "endswith",
+	ConditionOperatorLike:        "like",
+	ConditionOperatorIs:          "is",
+}
+
+// queryKeywords 不加引号时不能作为值的关键字
+var queryKeywords = map[string]bool{
+	"and":    true,
+	"order":  true,
+	"fields": true,
+	"limit":  true,
+	"offset": true,
+}
+
+// QueryError 查询语句解析错误
+type QueryError struct {
+	// Query 查询语句
+	Query string
+	// Offset 出错位置的字节偏移
+	Offset int
+	// Msg 错误描述
+	Msg string
+}
+
+// Column 出错位置所在的列, 从1开始按字符计数
+func (e *QueryError) Column() int {
+	return utf8.RuneCountInString(e.Query[:e.Offset]) + 1
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("asa: query: column %d: %s", e.Column(), e.Msg)
+}
+
+type queryTokenKind int
+
+const (
+	queryEOF queryTokenKind = iota
+	queryWord
+	queryString
+	queryPunct
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+}
+
+func (t queryToken) String() string {
+	switch t.kind {
+	case queryEOF:
+		return "end of query"
+	case queryString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// isQueryWordByte 不加引号的值可以包含的字符
+func isQueryWordByte(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c >= utf8.RuneSelf:
+		return true
+	}
+	return strings.IndexByte("_-.:+/@*%", c) >= 0
+}
+
+// lexQuery 将查询语句切分为token
+func lexQuery(src string) ([]queryToken, error) {
+	var tokens []queryToken
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(src) && src[j] != '"'; j++ {
+				if src[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(src) {
+				return nil, &QueryError{Query: src, Offset: i, Msg: "unterminated string"}
+			}
+			s, err := strconv.Unquote(src[i : j+1])
+			if err != nil {
+				return nil, &QueryError{Query: src, Offset: i, Msg: "invalid string " + src[i:j+1]}
+			}
+			tokens = append(tokens, queryToken{kind: queryString, text: s, pos: i})
+			i = j + 1
+		case c == '(' || c == ')' || c == ',':
+			tokens = append(tokens, queryToken{kind: queryPunct, text: src[i : i+1], pos: i})
+			i++
+		case c == '=' || c == '!' || c == '<' || c == '>':
+			n := 1
+			if i+1 < len(src) {
+				switch src[i : i+2] {
+				case "==", "!=", "<>", "<=", ">=":
+					n = 2
+				}
+			}
+			if src[i:i+n] == "!" {
+				return nil, &QueryError{Query: src, Offset: i, Msg: `unexpected character "!", did you mean "!="`}
+			}
+			tokens = append(tokens, queryToken{kind: queryPunct, text: src[i : i+n], pos: i})
+			i += n
+		case isQueryWordByte(c):
+			j := i
+			for j < len(src) && isQueryWordByte(src[j]) {
+				j++
+			}
+			tokens = append(tokens, queryToken{kind: queryWord, text: src[i:j], pos: i})
+			i = j
+		default:
+			r, _ := utf8.DecodeRuneInString(src[i:])
+			return nil, &QueryError{Query: src, Offset: i, Msg: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+	return append(tokens, queryToken{kind: queryEOF, pos: len(src)}), nil
+}
+
+type queryParser struct {
+	src    string
+	tokens []queryToken
+	i      int
+	// resource 校验字段名使用的资源类型, 为空时不校验
+	resource SelectorResource
+}
+
+// ParseSelector 将查询语句编译为 Selector, 不校验字段名
+//
+// 解析失败时返回 *QueryError, 包含出错的位置。
+func ParseSelector(query string) (*Selector, error) {
+	return parseSelector(query, "")
+}
+
+// ParseSelectorFor 将查询语句编译为 Selector, 并校验字段名是否属于resource
+func ParseSelectorFor(resource SelectorResource, query string) (*Selector, error) {
+	if selectorFieldSet(resource, selectorFieldReturn) == nil {
+		return nil, fmt.Errorf("asa: query: unknown resource %q", resource)
+	}
+	return parseSelector(query, resource)
+}
+
+func parseSelector(query string, resource SelectorResource) (*Selector, error) {
+	tokens, err := lexQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{src: query, tokens: tokens, resource: resource}
+	return p.parse()
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.i]
+}
+
+func (p *queryParser) next() queryToken {
+	t := p.tokens[p.i]
+	if t.kind != queryEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *queryParser) errorf(t queryToken, format string, args ...interface{}) error {
+	return &QueryError{Query: p.src, Offset: t.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// isKeyword 判断token是否为关键字kw
+func isKeyword(t queryToken, kw string) bool {
+	return t.kind == queryWord && strings.EqualFold(t.text, kw)
+}
+
+// isClause 判断token是否为子句的开始
+func isClause(t queryToken) bool {
+	return isKeyword(t, "order") || isKeyword(t, "fields") || isKeyword(t, "limit") || isKeyword(t, "offset")
+}
+
+func (p *queryParser) parse() (*Selector, error) {
+	s := &Selector{}
+	if t := p.peek(); t.kind != queryEOF && !isClause(t) {
+		for {
+			cond, err := p.condition()
+			if err != nil {
+				return nil, err
+			}
+			s.Conditions = append(s.Conditions, cond)
+			if !isKeyword(p.peek(), "and") {
+				break
+			}
+			p.next()
+		}
+	}
+
+	seen := make(map[string]bool)
+	for p.peek().kind != queryEOF {
+		t := p.next()
+		if !isClause(t) {
+			return nil, p.errorf(t, "expected and, fields, order by, limit or offset, got %s", t)
+		}
+		clause := strings.ToLower(t.text)
+		if seen[clause] {
+			return nil, p.errorf(t, "duplicate %s clause", clause)
+		}
+		seen[clause] = true
+		switch clause {
+		case "fields":
+			for {
+				field, err := p.field(selectorFieldReturn)
+				if err != nil {
+					return nil, err
+				}
+				s.Fields = append(s.Fields, field)
+				if !p.punct(",") {
+					break
+				}
+			}
+		case "order":
+			if t := p.next(); !isKeyword(t, "by") {
+				return nil, p.errorf(t, "expected by, got %s", t)
+			}
+			for {
+				sorting, err := p.sorting()
+				if err != nil {
+					return nil, err
+				}
+				s.OrderBy = append(s.OrderBy, sorting)
+				if !p.punct(",") {
+					break
+				}
+			}
+		case "limit", "offset":
+			numTok := p.peek()
+			n, err := p.number()
+			if err != nil {
+				return nil, err
+			}
+			if clause == "limit" && n > maxSelectorLimit {
+				return nil, p.errorf(numTok, "limit %d exceeds %d", n, maxSelectorLimit)
+			}
+			if s.Pagination == nil {
+				s.Pagination = &Pagination{}
+			}
+			if clause == "limit" {
+				s.Pagination.Limit = n
+			} else {
+				s.Pagination.Offset = n
+			}
+		}
+	}
+	return s, nil
+}
+
+// punct 下一个token为标点s时消费并返回true
+func (p *queryParser) punct(s string) bool {
+	if t := p.peek(); t.kind == queryPunct && t.text == s {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// field 解析字段名, 指定了资源类型时校验字段是否可用于use
+func (p *queryParser) field(use selectorFieldKind) (string, error) {
+	t := p.next()
+	if t.kind != queryWord || queryKeywords[strings.ToLower(t.text)] {
+		return "", p.errorf(t, "expected field, got %s", t)
+	}
+	if p.resource == "" || selectorFieldSet(p.resource, use)[t.text] {
+		return t.text, nil
+	}
+	if !selectorFieldSet(p.resource, selectorFieldReturn)[t.text] {
+		return "", p.errorf(t, "unknown %s field %q", p.resource, t.text)
+	}
+	return "", p.errorf(t, "%s field %q is not %s", p.resource, t.text, use)
+}
+
+func (p *queryParser) value() (string, error) {
+	t := p.next()
+	switch {
+	case t.kind == queryString:
+		return t.text, nil
+	case t.kind == queryWord && queryKeywords[strings.ToLower(t.text)]:
+		return "", p.errorf(t, "expected value, got keyword %s (quote it to use as a value)", t)
+	case t.kind == queryWord:
+		return t.text, nil
+	}
+	return "", p.errorf(t, "expected value, got %s", t)
+}
+
+func (p *queryParser) number() (uint32, error) {
+	t := p.next()
+	if t.kind != queryWord {
+		return 0, p.errorf(t, "expected number, got %s", t)
+	}
+	n, err := strconv.ParseUint(t.text, 10, 32)
+	if err != nil {
+		return 0, p.errorf(t, "invalid number %s", t)
+	}
+	return uint32(n), nil
+}
+
+func (p *queryParser) condition() (*Condition, error) {
+	field, err := p.field(selectorFieldCondition)
+	if err != nil {
+		return nil, err
+	}
+	opTok := p.next()
+	operator, ok := queryOperators[strings.ToLower(opTok.text)]
+	if !ok || opTok.kind == queryString || opTok.kind == queryEOF {
+		if opTok.text == ">=" || opTok.text == "<=" {
+			return nil, p.errorf(opTok, "operator %s is not supported", opTok)
+		}
+		return nil, p.errorf(opTok, "expected operator, got %s", opTok)
+	}
+	cond := &Condition{Field: field, Operator: operator}
+	switch {
+	case p.punct("("):
+		for {
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			cond.Values = append(cond.Values, v)
+			if p.punct(")") {
+				break
+			}
+			if t := p.next(); t.kind != queryPunct || t.text != "," {
+				return nil, p.errorf(t, `expected "," or ")", got %s`, t)
+			}
+		}
+	case operator == ConditionOperatorBetween:
+		from, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); !isKeyword(t, "and") {
+			return nil, p.errorf(t, "expected and, got %s", t)
+		}
+		to, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		cond.Values = []string{from, to}
+	default:
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		cond.Values = []string{v}
+	}
+	if err := validateCondition(cond); err != nil {
+		return nil, p.errorf(opTok, "%s", err)
+	}
+	return cond, nil
+}
+
+func (p *queryParser) sorting() (*Sorting, error) {
+	field, err := p.field(selectorFieldSort)
+	if err != nil {
+		return nil, err
+	}
+	sorting := &Sorting{Field: field, SortOrder: SortingOrderAscending}
+	t := p.peek()
+	switch {
+	case isKeyword(t, "asc"), isKeyword(t, "ascending"):
+		p.next()
+	case isKeyword(t, "desc"), isKeyword(t, "descending"):
+		p.next()
+		sorting.SortOrder = SortingOrderDescending
+	}
+	return sorting, nil
+}
+
+// FormatSelector 将 Selector 格式化为查询语句, 结果可以由 ParseSelector 解析回相同的 Selector
+func FormatSelector(s *Selector) string {
+	if s == nil {
+		return ""
+	}
+	var parts []string
+	var conds []string
+	for _, cond := range s.Conditions {
+		if cond != nil {
+			conds = append(conds, formatCondition(cond))
+		}
+	}
+	if len(conds) > 0 {
+		parts = append(parts, strings.Join(conds, " and "))
+	}
+	if len(s.Fields) > 0 {
+		parts = append(parts, "fields "+strings.Join(s.Fields, ", "))
+	}
+	var sorts []string
+	for _, sorting := range s.OrderBy {
+		if sorting == nil {
+			continue
+		}
+		switch sorting.SortOrder {
+		case SortingOrderDescending:
+			sorts = append(sorts, sorting.Field+" desc")
+		default:
+			sorts = append(sorts, sorting.Field+" asc")
+		}
+	}
+	if len(sorts) > 0 {
+		parts = append(parts, "order by "+strings.Join(sorts, ", "))
+	}
+	if s.Pagination != nil {
+		if s.Pagination.Limit > 0 {
+			parts = append(parts, fmt.Sprintf("limit %d", s.Pagination.Limit))
+		}
+		if s.Pagination.Offset > 0 {
+			parts = append(parts, fmt.Sprintf("offset %d", s.Pagination.Offset))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// String 以查询语句的形式输出 Selector, 便于记录日志
+func (s *Selector) String() string {
+	return FormatSelector(s)
+}
+
+func formatCondition(cond *Condition) string {
+	op, ok := formatOperators[cond.Operator]
+	if !ok {
+		op = strings.ToLower(string(cond.Operator))
+	}
+	values := make([]string, len(cond.Values))
+	for i, v := range cond.Values {
+		values[i] = formatQueryValue(v)
+	}
+	switch {
+	case cond.Operator == ConditionOperatorBetween && len(values) == 2:
+		return fmt.Sprintf("%s %s %s and %s", cond.Field, op, values[0], values[1])
+	case len(values) == 1 && cond.Operator != ConditionOperatorIn &&
+		cond.Operator != ConditionOperatorContainsAll && cond.Operator != ConditionOperatorContainsAny:
+		return fmt.Sprintf("%s %s %s", cond.Field, op, values[0])
+	}
+	return fmt.Sprintf("%s %s (%s)", cond.Field, op, strings.Join(values, ", "))
+}
+
+// formatQueryValue 值为空、包含特殊字符或与关键字相同时加引号
+func formatQueryValue(v string) string {
+	if v == "" || queryKeywords[strings.ToLower(v)] {
+		return strconv.Quote(v)
+	}
+	for i := 0; i < len(v); i++ {
+		if !isQueryWordByte(v[i]) {
+			return strconv.Quote(v)
+		}
+	}
+	return v
+}
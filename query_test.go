@@ -0,0 +1,146 @@
+package asa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -run TestParseSelector
+func TestParseSelector(t *testing.T) {
+	t.Parallel()
+
+	selector, err := ParseSelector(`status = ENABLED and name startswith "US_" order by modificationTime desc limit 100`)
+	assert.NoError(t, err)
+	assert.Equal(t, &Selector{
+		Conditions: []*Condition{
+			{Field: "status", Operator: ConditionOperatorEquals, Values: []string{"ENABLED"}},
+			{Field: "name", Operator: ConditionOperatorStartsWith, Values: []string{"US_"}},
+		},
+		OrderBy:    []*Sorting{{Field: "modificationTime", SortOrder: SortingOrderDescending}},
+		Pagination: &Pagination{Limit: 100},
+	}, selector)
+
+	cases := []struct {
+		query    string
+		operator ConditionOperator
+		values   []string
+	}{
+		{`f == 1`, ConditionOperatorEquals, []string{"1"}},
+		{`f EQUALS 1`, ConditionOperatorEquals, []string{"1"}},
+		{`f <> 1`, ConditionOperatorNotEqual, []string{"1"}},
+		{`f not_equals 1`, ConditionOperatorNotEqual, []string{"1"}},
+		{`f > 1.5`, ConditionOperatorGreaterThan, []string{"1.5"}},
+		{`f < -1`, ConditionOperatorLessThan, []string{"-1"}},
+		{`f in (A, "B C")`, ConditionOperatorIn, []string{"A", "B C"}},
+		{`f between 2024-01-01 and 2024-02-01`, ConditionOperatorBetween, []string{"2024-01-01", "2024-02-01"}},
+		{`f between (1, 2)`, ConditionOperatorBetween, []string{"1", "2"}},
+		{`f contains US`, ConditionOperatorContains, []string{"US"}},
+		{`f contains_all (US, GB)`, ConditionOperatorContainsAll, []string{"US", "GB"}},
+		{`f contains_any (US)`, ConditionOperatorContainsAny, []string{"US"}},
+		{`f endswith "_x"`, ConditionOperatorEndsWith, []string{"_x"}},
+		{`f like "%a\"b%"`, ConditionOperatorLike, []string{`%a"b%`}},
+		{`f is true`, ConditionOperatorIs, []string{"true"}},
+		{`f = "and"`, ConditionOperatorEquals, []string{"and"}},
+	}
+	for _, c := range cases {
+		selector, err := ParseSelector(c.query)
+		if assert.NoError(t, err, c.query) {
+			assert.Equal(t, []*Condition{{Field: "f", Operator: c.operator, Values: c.values}}, selector.Conditions, c.query)
+		}
+	}
+
+	selector, err = ParseSelector(`LIMIT 10 fields id, name OFFSET 20 ORDER BY name, id desc`)
+	assert.NoError(t, err)
+	assert.Equal(t, &Selector{
+		Fields: []string{"id", "name"},
+		OrderBy: []*Sorting{
+			{Field: "name", SortOrder: SortingOrderAscending},
+			{Field: "id", SortOrder: SortingOrderDescending},
+		},
+		Pagination: &Pagination{Limit: 10, Offset: 20},
+	}, selector)
+
+	selector, err = ParseSelector("  ")
+	assert.NoError(t, err)
+	assert.Equal(t, &Selector{}, selector)
+}
+
+// go test -v -run TestParseSelectorErrors
+func TestParseSelectorErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		query  string
+		column int
+		msg    string
+	}{
+		{`status = `, 10, "expected value, got end of query"},
+		{`status ENABLED`, 8, `expected operator, got "ENABLED"`},
+		{`status >= 1`, 8, `operator ">=" is not supported`},
+		{`status = and`, 10, `expected value, got keyword "and" (quote it to use as a value)`},
+		{`id between 1 5`, 14, `expected and, got "5"`},
+		{`id between (1)`, 4, "id: BETWEEN requires 2 value(s), got 1"},
+		{`id in (1 2)`, 10, `expected "," or ")", got "2"`},
+		{`name = "abc`, 8, "unterminated string"},
+		{`name = a; id = 1`, 9, `unexpected character ';'`},
+		{`name ! a`, 6, `unexpected character "!", did you mean "!="`},
+		{`a = 1 b = 2`, 7, `expected and, fields, order by, limit or offset, got "b"`},
+		{`a = 1 limit x`, 13, `invalid number "x"`},
+		{`a = 1 limit 2000`, 13, "limit 2000 exceeds 1000"},
+		{`limit 1 limit 2`, 9, "duplicate limit clause"},
+		{`order name`, 7, `expected by, got "name"`},
+		{`名称 = 1 and = 2`, 12, `expected field, got "="`},
+	}
+	for _, c := range cases {
+		_, err := ParseSelector(c.query)
+		var qErr *QueryError
+		if assert.True(t, errors.As(err, &qErr), c.query) {
+			assert.Equal(t, c.column, qErr.Column(), c.query)
+			assert.Equal(t, c.msg, qErr.Msg, c.query)
+		}
+	}
+
+	_, err := ParseSelectorFor(SelectorResourceCampaign, `status = ENABLED and budget > 1`)
+	assert.EqualError(t, err, `asa: query: column 22: unknown campaign field "budget"`)
+	_, err = ParseSelectorFor(SelectorResourceCampaign, `budgetAmount > 1`)
+	assert.EqualError(t, err, `asa: query: column 1: campaign field "budgetAmount" is not filterable`)
+	_, err = ParseSelectorFor(SelectorResourceCampaign, `order by countriesOrRegions`)
+	assert.EqualError(t, err, `asa: query: column 10: campaign field "countriesOrRegions" is not sortable`)
+	_, err = ParseSelectorFor(SelectorResourceCampaign, `fields budgetAmount, locInvoiceDetails order by budgetAmount`)
+	assert.NoError(t, err)
+	_, err = ParseSelectorFor("unknown", `id = 1`)
+	assert.Error(t, err)
+}
+
+// go test -v -run TestFormatSelector
+func TestFormatSelector(t *testing.T) {
+	t.Parallel()
+
+	selector := NewSelector().
+		Where("status").Equals("ENABLED").
+		Where("name").StartsWith("US _").
+		Where("id").In(1, 2).
+		Where("startTime").Between("2024-01-01", "2024-02-01").
+		Where("countriesOrRegions").ContainsAny("US").
+		Where("text").Equals("").
+		Where("text").NotEquals("Order").
+		Fields("id", "name").
+		OrderBy("modificationTime", SortingOrderDescending).
+		Limit(100).
+		Offset(5).
+		MustBuild()
+	text := selector.String()
+	assert.Equal(t, `status = ENABLED and name startswith "US _" and id in (1, 2) and `+
+		`startTime between 2024-01-01 and 2024-02-01 and countriesOrRegions contains_any (US) and `+
+		`text = "" and text != "Order" fields id, name order by modificationTime desc limit 100 offset 5`, text)
+
+	parsed, err := ParseSelector(text)
+	assert.NoError(t, err)
+	assert.Equal(t, selector, parsed)
+
+	assert.Equal(t, "", FormatSelector(nil))
+	var nilSelector *Selector
+	assert.Equal(t, "", nilSelector.String())
+}
@@ -0,0 +1,135 @@
+package asa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter 请求限流器, 每次请求前调用 Wait, 收到响应后调用 Observe
+type RateLimiter interface {
+	// Wait 阻塞直到orgID允许发出下一次请求, ctx结束时返回ctx.Err()
+	Wait(ctx context.Context, orgID int64) error
+	// Observe 反馈响应状态码, 用于自适应调整速率
+	Observe(orgID int64, statusCode int)
+}
+
+// TokenBucketLimiter 按orgID分桶的令牌桶限流器, 可在多个goroutine和客户端之间共享
+//
+// 收到429时该org的速率减半(不低于 MinRate), 之后每次成功响应逐步恢复到初始速率。
+type TokenBucketLimiter struct {
+	// MinRate 自适应降速的下限(每秒请求数)
+	MinRate float64
+
+	mu      sync.Mutex
+	rate    float64
+	burst   int
+	buckets map[int64]*tokenBucket
+}
+
+// tokenBucket 单个org的令牌桶
+type tokenBucket struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucketLimiter 创建令牌桶限流器, ratePerSecond 为每个org每秒允许的请求数, burst 为突发容量
+//
+// ratePerSecond 必须大于0, 否则panic。
+func NewTokenBucketLimiter(ratePerSecond float64, burst int) *TokenBucketLimiter {
+	if !(ratePerSecond > 0) {
+		panic(fmt.Sprintf("asa: NewTokenBucketLimiter: rate must be positive, got %v", ratePerSecond))
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucketLimiter{
+		MinRate: ratePerSecond / 16,
+		rate:    ratePerSecond,
+		burst:   burst,
+		buckets: make(map[int64]*tokenBucket),
+	}
+}
+
+// bucket 获取orgID对应的令牌桶, 调用方需持有锁
+func (l *TokenBucketLimiter) bucket(orgID int64, now time.Time) *tokenBucket {
+	b, ok := l.buckets[orgID]
+	if !ok {
+		b = &tokenBucket{rate: l.rate, tokens: float64(l.burst), last: now}
+		l.buckets[orgID] = b
+		return b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > float64(l.burst) {
+		b.tokens = float64(l.burst)
+	}
+	b.last = now
+	return b
+}
+
+// Wait 预留一个令牌并等待其可用
+func (l *TokenBucketLimiter) Wait(ctx context.Context, orgID int64) error {
+	l.mu.Lock()
+	b := l.bucket(orgID, time.Now())
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// 归还未使用的令牌
+		l.mu.Lock()
+		b.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Observe 收到429时降速, 成功时逐步恢复
+func (l *TokenBucketLimiter) Observe(orgID int64, statusCode int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := l.bucket(orgID, time.Now())
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		b.rate /= 2
+		if b.rate < l.MinRate {
+			b.rate = l.MinRate
+		}
+		if b.tokens > 0 {
+			b.tokens = 0
+		}
+	case statusCode < http.StatusBadRequest && b.rate < l.rate:
+		b.rate += l.rate / 10
+		if b.rate > l.rate {
+			b.rate = l.rate
+		}
+	}
+}
+
+// Rate 返回orgID当前的速率(每秒请求数)
+func (l *TokenBucketLimiter) Rate(orgID int64) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.bucket(orgID, time.Now()).rate
+}
+
+// SetRateLimiter 设置请求限流器, 传nil关闭限流
+func (c *Client) SetRateLimiter(limiter RateLimiter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimiter = limiter
+}
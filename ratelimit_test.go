@@ -0,0 +1,82 @@
+package asa
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -run TestTokenBucketLimiterWait
+func TestTokenBucketLimiterWait(t *testing.T) {
+	t.Parallel()
+
+	l := NewTokenBucketLimiter(50, 1)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, l.Wait(context.Background(), 1))
+		}()
+	}
+	wg.Wait()
+	// 1个突发令牌 + 4个按每秒50个补充, 至少需要80ms
+	assert.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+
+	// 不同org互不影响, 无需等待时不检查ctx
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, l.Wait(ctx, 2))
+
+	assert.Panics(t, func() { NewTokenBucketLimiter(0, 1) })
+	assert.Panics(t, func() { NewTokenBucketLimiter(-1, 1) })
+}
+
+// go test -v -run TestTokenBucketLimiterAdaptive
+func TestTokenBucketLimiterAdaptive(t *testing.T) {
+	t.Parallel()
+
+	l := NewTokenBucketLimiter(16, 1)
+	l.Observe(1, http.StatusTooManyRequests)
+	assert.Equal(t, float64(8), l.Rate(1))
+	for i := 0; i < 10; i++ {
+		l.Observe(1, http.StatusTooManyRequests)
+	}
+	assert.Equal(t, l.MinRate, l.Rate(1))
+	for i := 0; i < 20; i++ {
+		l.Observe(1, http.StatusOK)
+	}
+	assert.Equal(t, float64(16), l.Rate(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	l.Observe(1, http.StatusTooManyRequests)
+	assert.ErrorIs(t, l.Wait(ctx, 1), context.Canceled)
+}
+
+// go test -v -run TestClientRateLimiter
+func TestClientRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	c.SetRetryPolicy(fastRetryPolicy())
+	limiter := NewTokenBucketLimiter(100, 10)
+	c.SetRateLimiter(limiter)
+
+	_, err := c.AccessControlList.GetUserACL()
+	assert.NoError(t, err)
+	assert.Less(t, limiter.Rate(0), float64(100))
+}
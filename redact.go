@@ -0,0 +1,82 @@
+package asa
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// redacted 脱敏后的占位内容
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders 需要脱敏的请求头与响应头
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveParams 需要脱敏的查询参数、表单字段与json字段
+var sensitiveParams = []string{"client_secret", "client_assertion", "access_token", "refresh_token", "id_token"}
+
+var (
+	// pemPattern PEM格式的私钥
+	pemPattern = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)
+	// jwtPattern JWT格式的字符串, 例如client secret
+	jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	// bearerPattern Authorization请求头
+	bearerPattern = regexp.MustCompile(`(?i)((?:proxy-)?authorization:\s*)[^\r\n]*`)
+	// paramPattern json字段或表单、查询参数中的敏感值
+	paramPattern = regexp.MustCompile(`("?(?:` + strings.Join(sensitiveParams, "|") + `)"?\s*[:=]\s*"?)[^"&\s,}]+`)
+)
+
+// redactHeader 返回敏感请求头已脱敏的副本
+func redactHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for _, k := range sensitiveHeaders {
+		v := out.Get(k)
+		if v == "" {
+			continue
+		}
+		if scheme, _, ok := strings.Cut(v, " "); ok {
+			out.Set(k, scheme+" "+redacted)
+		} else {
+			out.Set(k, redacted)
+		}
+	}
+	return out
+}
+
+// redactURL 返回敏感查询参数已脱敏的地址
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return redactText(rawURL)
+	}
+	q := u.Query()
+	changed := false
+	for _, k := range sensitiveParams {
+		if q.Has(k) {
+			q.Set(k, redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return rawURL
+	}
+	// 保持可读性, 不对占位内容进行转义
+	u.RawQuery = strings.ReplaceAll(q.Encode(), url.QueryEscape(redacted), redacted)
+	return u.String()
+}
+
+// redactText 对请求或响应内容中的token、client secret和私钥进行脱敏
+func redactText(s string) string {
+	s = pemPattern.ReplaceAllString(s, redacted)
+	s = bearerPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := bearerPattern.FindStringSubmatch(m)
+		v := strings.TrimPrefix(m, sub[1])
+		if scheme, _, ok := strings.Cut(v, " "); ok {
+			return sub[1] + scheme + " " + redacted
+		}
+		return sub[1] + redacted
+	})
+	s = paramPattern.ReplaceAllString(s, "${1}"+redacted)
+	return jwtPattern.ReplaceAllString(s, redacted)
+}
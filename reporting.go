@@ -17,6 +17,7 @@ along with apple-search-ads-go.  If not, see <http://www.gnu.org/licenses/>.
 package asa
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -250,9 +251,14 @@ type ReportingDataResponse struct {
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_campaign-level_reports
 func (s *ReportingService) GetCampaignLevelReports(params *ReportingRequest) (*ReportingResponseBody, error) {
+	return s.GetCampaignLevelReportsWithContext(context.Background(), params)
+}
+
+// GetCampaignLevelReportsWithContext is like GetCampaignLevelReports but uses ctx to cancel the request or bound its deadline.
+func (s *ReportingService) GetCampaignLevelReportsWithContext(ctx context.Context, params *ReportingRequest) (*ReportingResponseBody, error) {
 	url := "reports/campaigns"
 	res := new(ReportingResponseBody)
-	err := s.client.post(url, res, params)
+	err := s.client.find(ctx, url, res, params)
 
 	return res, err
 }
@@ -261,9 +267,14 @@ func (s *ReportingService) GetCampaignLevelReports(params *ReportingRequest) (*R
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_ad_group-level_reports
 func (s *ReportingService) GetAdGroupLevelReports(campaignID int64, params *ReportingRequest) (*ReportingResponseBody, error) {
+	return s.GetAdGroupLevelReportsWithContext(context.Background(), campaignID, params)
+}
+
+// GetAdGroupLevelReportsWithContext is like GetAdGroupLevelReports but uses ctx to cancel the request or bound its deadline.
+func (s *ReportingService) GetAdGroupLevelReportsWithContext(ctx context.Context, campaignID int64, params *ReportingRequest) (*ReportingResponseBody, error) {
 	url := fmt.Sprintf("reports/campaigns/%d/adgroups", campaignID)
 	res := new(ReportingResponseBody)
-	err := s.client.post(url, res, params)
+	err := s.client.find(ctx, url, res, params)
 
 	return res, err
 }
@@ -272,9 +283,14 @@ func (s *ReportingService) GetAdGroupLevelReports(campaignID int64, params *Repo
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_keyword-level_reports
 func (s *ReportingService) GetKeywordLevelReports(campaignID int64, params *ReportingRequest) (*ReportingResponseBody, error) {
+	return s.GetKeywordLevelReportsWithContext(context.Background(), campaignID, params)
+}
+
+// GetKeywordLevelReportsWithContext is like GetKeywordLevelReports but uses ctx to cancel the request or bound its deadline.
+func (s *ReportingService) GetKeywordLevelReportsWithContext(ctx context.Context, campaignID int64, params *ReportingRequest) (*ReportingResponseBody, error) {
 	url := fmt.Sprintf("reports/campaigns/%d/keywords", campaignID)
 	res := new(ReportingResponseBody)
-	err := s.client.post(url, res, params)
+	err := s.client.find(ctx, url, res, params)
 
 	return res, err
 }
@@ -283,9 +299,14 @@ func (s *ReportingService) GetKeywordLevelReports(campaignID int64, params *Repo
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_keyword-level_within_ad_group_reports
 func (s *ReportingService) GetAdGroupKeywordLevelReports(campaignID, adGroupID int64, params *ReportingRequest) (*ReportingResponseBody, error) {
+	return s.GetAdGroupKeywordLevelReportsWithContext(context.Background(), campaignID, adGroupID, params)
+}
+
+// GetAdGroupKeywordLevelReportsWithContext is like GetAdGroupKeywordLevelReports but uses ctx to cancel the request or bound its deadline.
+func (s *ReportingService) GetAdGroupKeywordLevelReportsWithContext(ctx context.Context, campaignID, adGroupID int64, params *ReportingRequest) (*ReportingResponseBody, error) {
 	url := fmt.Sprintf("reports/campaigns/%d/adgroups/%d/keywords", campaignID, adGroupID)
 	res := new(ReportingResponseBody)
-	err := s.client.post(url, res, params)
+	err := s.client.find(ctx, url, res, params)
 
 	return res, err
 }
@@ -294,9 +315,14 @@ func (s *ReportingService) GetAdGroupKeywordLevelReports(campaignID, adGroupID i
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_search_term-level_reports
 func (s *ReportingService) GetSearchTermLevelReports(campaignID int64, params *ReportingRequest) (*ReportingResponseBody, error) {
+	return s.GetSearchTermLevelReportsWithContext(context.Background(), campaignID, params)
+}
+
+// GetSearchTermLevelReportsWithContext is like GetSearchTermLevelReports but uses ctx to cancel the request or bound its deadline.
+func (s *ReportingService) GetSearchTermLevelReportsWithContext(ctx context.Context, campaignID int64, params *ReportingRequest) (*ReportingResponseBody, error) {
 	url := fmt.Sprintf("reports/campaigns/%d/searchterms", campaignID)
 	res := new(ReportingResponseBody)
-	err := s.client.post(url, res, params)
+	err := s.client.find(ctx, url, res, params)
 
 	return res, err
 }
@@ -305,9 +331,14 @@ func (s *ReportingService) GetSearchTermLevelReports(campaignID int64, params *R
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_search_term-level_within_ad_group_reports
 func (s *ReportingService) GetAdGroupSearchTermLevelReports(campaignID, adGroupID int64, params *ReportingRequest) (*ReportingResponseBody, error) {
+	return s.GetAdGroupSearchTermLevelReportsWithContext(context.Background(), campaignID, adGroupID, params)
+}
+
+// GetAdGroupSearchTermLevelReportsWithContext is like GetAdGroupSearchTermLevelReports but uses ctx to cancel the request or bound its deadline.
+func (s *ReportingService) GetAdGroupSearchTermLevelReportsWithContext(ctx context.Context, campaignID, adGroupID int64, params *ReportingRequest) (*ReportingResponseBody, error) {
 	url := fmt.Sprintf("reports/campaigns/%d/adgroups/%d/searchterms", campaignID, adGroupID)
 	res := new(ReportingResponseBody)
-	err := s.client.post(url, res, params)
+	err := s.client.find(ctx, url, res, params)
 
 	return res, err
 }
@@ -316,9 +347,14 @@ func (s *ReportingService) GetAdGroupSearchTermLevelReports(campaignID, adGroupI
 //
 // https://developer.apple.com/documentation/apple_search_ads/get_creative_set-level_reports
 func (s *ReportingService) GetCreativeSetLevelReports(campaignID int64, params *ReportingRequest) (*ReportingResponseBody, error) {
+	return s.GetCreativeSetLevelReportsWithContext(context.Background(), campaignID, params)
+}
+
+// GetCreativeSetLevelReportsWithContext is like GetCreativeSetLevelReports but uses ctx to cancel the request or bound its deadline.
+func (s *ReportingService) GetCreativeSetLevelReportsWithContext(ctx context.Context, campaignID int64, params *ReportingRequest) (*ReportingResponseBody, error) {
 	url := fmt.Sprintf("reports/campaigns/%d/creativesets", campaignID)
 	res := new(ReportingResponseBody)
-	err := s.client.post(url, res, params)
+	err := s.client.find(ctx, url, res, params)
 
 	return res, err
 }
@@ -0,0 +1,118 @@
+package asa
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+	"unsafe"
+
+	"github.com/ropon/requests/v2"
+)
+
+// requests.Request 没有导出 SetHeader 写入的请求头以及代理、超时所在的http.Client,
+// 这里通过反射读取, 使 NewClient 传入的 requests.Request 配置对接口请求生效
+
+var (
+	requestFieldsOnce sync.Once
+	requestHTTPReq    []int
+	requestClient     []int
+	requestFieldsErr  error
+)
+
+// requestField 获取req中的未导出字段, 调用方需保证没有并发修改req
+func requestField(req *requests.Request, name string) (reflect.Value, error) {
+	requestFieldsOnce.Do(func() {
+		typ := reflect.TypeOf(requests.Request{})
+		lookup := func(name string, want reflect.Type) []int {
+			f, ok := typ.FieldByName(name)
+			if !ok || f.Type != want {
+				requestFieldsErr = fmt.Errorf("asa: unsupported requests.Request version, field %s of type %s not found", name, want)
+				return nil
+			}
+			return f.Index
+		}
+		requestHTTPReq = lookup("httpReq", reflect.TypeOf(&http.Request{}))
+		requestClient = lookup("client", reflect.TypeOf(&http.Client{}))
+	})
+	if requestFieldsErr != nil {
+		return reflect.Value{}, requestFieldsErr
+	}
+	index := requestClient
+	if name == "httpReq" {
+		index = requestHTTPReq
+	}
+	f := reflect.ValueOf(req).Elem().FieldByIndex(index)
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem(), nil
+}
+
+// requestHeader 获取req发送请求时使用的请求头副本, 包括 SetHeader 设置的请求头与 Headers 字段
+func requestHeader(req *requests.Request) (http.Header, error) {
+	f, err := requestField(req, "httpReq")
+	if err != nil {
+		return nil, err
+	}
+	header := make(http.Header)
+	if httpReq := f.Interface().(*http.Request); httpReq != nil {
+		header = httpReq.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+	}
+	for k, v := range req.Headers {
+		header.Set(k, v)
+	}
+	return header, nil
+}
+
+// requestHTTPClient 获取req内部使用的http.Client, 包含 SetProxy、SetTimeout 的配置
+//
+// 返回的http.Client与req共用, 修改时调用方需持有锁。
+func requestHTTPClient(req *requests.Request) (*http.Client, error) {
+	f, err := requestField(req, "client")
+	if err != nil {
+		return nil, err
+	}
+	httpClient := f.Interface().(*http.Client)
+	if httpClient == nil {
+		return nil, fmt.Errorf("asa: requests.Request has no http client")
+	}
+	return httpClient, nil
+}
+
+// setRequestHeader 替换req发送请求时使用的请求头, 调用方需保证没有并发使用req
+func setRequestHeader(req *requests.Request, header http.Header) error {
+	f, err := requestField(req, "httpReq")
+	if err != nil {
+		return err
+	}
+	httpReq := f.Interface().(*http.Request)
+	if httpReq == nil {
+		return fmt.Errorf("asa: requests.Request has no http request")
+	}
+	httpReq.Header = header
+	return nil
+}
+
+// requestConfig 接口请求使用的 requests.Request 配置快照
+type requestConfig struct {
+	baseURL    *url.URL
+	header     http.Header
+	debug      bool
+	httpClient *http.Client
+}
+
+// readRequestConfig 读取req的配置, http.Client为副本, 调用方需保证没有并发修改req
+func readRequestConfig(req *requests.Request) (*requestConfig, error) {
+	header, err := requestHeader(req)
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := requestHTTPClient(req)
+	if err != nil {
+		return nil, err
+	}
+	hc := *httpClient
+	return &requestConfig{baseURL: req.BaseUrl, header: header, debug: req.Debug, httpClient: &hc}, nil
+}
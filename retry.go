@@ -0,0 +1,204 @@
+package asa
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// RetryPolicy 重试策略
+//
+// 默认只重放幂等请求(GET/PUT/DELETE), Find* 与报表这类只读的POST查询需要开启 RetryFind,
+// 其余POST可以通过 WithRetry 按调用单独开启。
+type RetryPolicy struct {
+	// MaxAttempts 最大尝试次数(包含首次请求), 小于等于1时不重试
+	MaxAttempts int
+	// MaxElapsedTime 重试总耗时上限, 为0时不限制
+	MaxElapsedTime time.Duration
+	// InitialInterval 首次重试等待时间
+	InitialInterval time.Duration
+	// MaxInterval 单次重试等待时间上限
+	MaxInterval time.Duration
+	// RetryableStatusCodes 需要重试的http状态码
+	RetryableStatusCodes []int
+	// RetryableError 判断请求错误是否需要重试, 为nil时使用 IsRetryableError
+	RetryableError func(err error) bool
+	// RetryFind 是否重试Find*与报表等只读的POST查询
+	RetryFind bool
+}
+
+// DefaultRetryPolicy 默认重试策略
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     3,
+		MaxElapsedTime:  time.Minute,
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     10 * time.Second,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// IsRetryableError 判断网络错误是否可以重试(超时、连接被重置或拒绝、连接意外断开)
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+type retryKey struct{}
+
+type findKey struct{}
+
+// WithRetry 标记本次调用可以安全重放, 例如调用方确认幂等的POST请求
+func WithRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryKey{}, true)
+}
+
+// withFind 标记本次调用为只读的POST查询
+func withFind(ctx context.Context) context.Context {
+	return context.WithValue(ctx, findKey{}, true)
+}
+
+// SetRetryPolicy 设置重试策略, 传nil关闭重试
+func (c *Client) SetRetryPolicy(policy *RetryPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.retryPolicy = policy
+}
+
+// replayable 判断请求是否允许重放
+func (p *RetryPolicy) replayable(ctx context.Context, method string) bool {
+	if p.MaxAttempts <= 1 {
+		return false
+	}
+	if v, _ := ctx.Value(retryKey{}).(bool); v {
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		v, _ := ctx.Value(findKey{}).(bool)
+		return p.RetryFind && v
+	}
+	return false
+}
+
+func (p *RetryPolicy) retryableStatus(code int) bool {
+	for _, c := range p.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) retryableError(err error) bool {
+	if p.RetryableError != nil {
+		return p.RetryableError(err)
+	}
+	return IsRetryableError(err)
+}
+
+// retryableStatusError 可重试的响应状态
+type retryableStatusError struct {
+	statusCode int
+}
+
+func (e *retryableStatusError) Error() string {
+	return "retryable status " + strconv.Itoa(e.statusCode)
+}
+
+// retryAfterBackOff 优先使用服务端Retry-After指定的等待时间
+//
+// Retry-After 不超过 MaxInterval, 等待后会超过 MaxElapsedTime 时不再重试。
+type retryAfterBackOff struct {
+	*backoff.ExponentialBackOff
+	retryAfter time.Duration
+}
+
+func (b *retryAfterBackOff) NextBackOff() time.Duration {
+	next := b.ExponentialBackOff.NextBackOff()
+	if next == backoff.Stop || b.retryAfter <= 0 {
+		return next
+	}
+	next, b.retryAfter = b.retryAfter, 0
+	if b.MaxInterval > 0 && next > b.MaxInterval {
+		next = b.MaxInterval
+	}
+	if b.MaxElapsedTime > 0 && b.GetElapsedTime()+next > b.MaxElapsedTime {
+		return backoff.Stop
+	}
+	return next
+}
+
+// parseRetryAfter 解析Retry-After响应头, 支持秒数与http日期
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if n, err := strconv.Atoi(v); err == nil && n > 0 {
+		return time.Duration(n) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// retry 按重试策略执行send, 重试用尽后保留最后一次响应交由调用方处理
+func (c *Client) retry(ctx context.Context, p *RetryPolicy, method string, send func() (*http.Response, error)) error {
+	if p == nil || !p.replayable(ctx, method) {
+		_, err := send()
+		return err
+	}
+
+	b := &retryAfterBackOff{ExponentialBackOff: backoff.NewExponentialBackOff(
+		backoff.WithInitialInterval(p.InitialInterval),
+		backoff.WithMaxInterval(p.MaxInterval),
+		backoff.WithMaxElapsedTime(p.MaxElapsedTime),
+	)}
+	bo := backoff.WithContext(backoff.WithMaxRetries(b, uint64(p.MaxAttempts-1)), ctx)
+
+	err := backoff.Retry(func() error {
+		res, err := send()
+		if err != nil {
+			if p.retryableError(err) {
+				return err
+			}
+			return backoff.Permanent(err)
+		}
+		if p.retryableStatus(res.StatusCode) {
+			b.retryAfter = parseRetryAfter(res.Header.Get("Retry-After"))
+			return &retryableStatusError{statusCode: res.StatusCode}
+		}
+		return nil
+	}, bo)
+
+	var statusErr *retryableStatusError
+	if errors.As(err, &statusErr) {
+		return nil
+	}
+	return err
+}
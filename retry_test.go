@@ -0,0 +1,68 @@
+package asa
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fastRetryPolicy() *RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.InitialInterval = time.Millisecond
+	p.MaxInterval = time.Millisecond
+	return p
+}
+
+// go test -v -run TestRetryIdempotentRequest
+func TestRetryIdempotentRequest(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	})
+	c.SetRetryPolicy(fastRetryPolicy())
+
+	res, err := c.Campaigns.GetCampaign(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.Campaign.ID)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+// go test -v -run TestRetryPostRequiresOptIn
+func TestRetryPostRequiresOptIn(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	policy := fastRetryPolicy()
+	c.SetRetryPolicy(policy)
+
+	_, _ = c.Campaigns.CreateCampaign(&Campaign{Name: "test"})
+	_, _ = c.Campaigns.FindCampaigns(&Selector{})
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	policy.RetryFind = true
+	_, _ = c.Campaigns.FindCampaigns(&Selector{})
+	assert.Equal(t, int32(2+policy.MaxAttempts), atomic.LoadInt32(&calls))
+}
+
+// go test -v -run TestParseRetryAfter
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 2*time.Second, parseRetryAfter("2"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+	assert.InDelta(t, float64(time.Minute), float64(parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))), float64(2*time.Second))
+}
@@ -0,0 +1,95 @@
+package asa
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxClientSecretLifetime Apple允许的client secret最长有效期(180天)
+//
+// https://developer.apple.com/documentation/apple_search_ads/implementing_oauth_for_the_apple_search_ads_api
+const MaxClientSecretLifetime = 180 * 24 * time.Hour
+
+// clientSecretExpiryMargin client secret过期前预留的时间, 避免换取access token时client secret刚好过期
+const clientSecretExpiryMargin = time.Minute
+
+// ClientSecretInfo client secret的签发信息
+type ClientSecretInfo struct {
+	// KeyID 签名使用的key id
+	KeyID string
+	// IssuedAt 签发时间
+	IssuedAt time.Time
+	// ExpiresAt 过期时间
+	ExpiresAt time.Time
+}
+
+// SetClientSecretLifetime 设置client secret有效期, 超过 MaxClientSecretLifetime 时按最大值处理
+//
+// client secret在过期前 clientSecretExpiryMargin 即重新签发, 有效期必须大于该时间。
+// 新的有效期从下一次生成client secret开始生效。
+func (t *TokenConfig) SetClientSecretLifetime(d time.Duration) error {
+	if d <= clientSecretExpiryMargin {
+		return fmt.Errorf("asa: invalid client secret lifetime %s", d)
+	}
+	if d > MaxClientSecretLifetime {
+		d = MaxClientSecretLifetime
+	}
+	g := t.jwtGenerator
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.expireDuration = d
+	return nil
+}
+
+// ClientSecretInfo 获取当前client secret的签发信息, 尚未生成client secret时返回false
+func (t *TokenConfig) ClientSecretInfo() (ClientSecretInfo, bool) {
+	g := t.jwtGenerator
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.token == "" {
+		return ClientSecretInfo{}, false
+	}
+	return g.secret, true
+}
+
+// OnClientSecretExpiry 设置client secret即将过期的提醒, 距离过期不足before时调用fn
+//
+// 提醒在生成或使用client secret时检查, 每个client secret只提醒一次; fn在新的goroutine中调用。
+// 过期后会使用当前私钥自动重新生成client secret, 提醒主要用于在私钥需要更换时及时处理。
+func (t *TokenConfig) OnClientSecretExpiry(before time.Duration, fn func(info ClientSecretInfo)) {
+	g := t.jwtGenerator
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.secretWarnBefore = before
+	g.onSecretExpiry = fn
+	g.checkSecretExpiry()
+}
+
+// RotateKey 更换签名使用的key id与私钥, 无需重新创建 TokenConfig 或 Client
+//
+// 更换后当前的client secret与access token立即失效, 下一次请求使用新私钥换取access token;
+// 更换时正在进行的换取结果会被丢弃。
+func (t *TokenConfig) RotateKey(keyID string, key *ecdsa.PrivateKey) error {
+	if keyID == "" {
+		return errors.New("asa: key id is empty")
+	}
+	if key == nil {
+		return errors.New("asa: private key is nil")
+	}
+	if key.Curve != elliptic.P256() {
+		return ErrInvalidKeyCurve
+	}
+	g := t.jwtGenerator
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.keyID = keyID
+	g.privateKey = key
+	g.token = ""
+	g.secret = ClientSecretInfo{}
+	g.accessToken = nil
+	g.generation++
+	return nil
+}
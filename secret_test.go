@@ -0,0 +1,168 @@
+package asa
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -run TestClientSecretLifetime
+func TestClientSecretLifetime(t *testing.T) {
+	t.Parallel()
+
+	auth, err := NewTokenConfig("client", "team", "key", newTestPrivateKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ok := auth.ClientSecretInfo()
+	assert.False(t, ok)
+
+	assert.Error(t, auth.SetClientSecretLifetime(0))
+	assert.Error(t, auth.SetClientSecretLifetime(time.Minute))
+	assert.NoError(t, auth.SetClientSecretLifetime(365*24*time.Hour))
+	secret, err := auth.GenerateClientSecret()
+	assert.NoError(t, err)
+
+	info, ok := auth.ClientSecretInfo()
+	assert.True(t, ok)
+	assert.Equal(t, "key", info.KeyID)
+	assert.Equal(t, MaxClientSecretLifetime, info.ExpiresAt.Sub(info.IssuedAt))
+
+	claims := &jwt.StandardClaims{}
+	parsed, _, err := new(jwt.Parser).ParseUnverified(secret, claims)
+	assert.NoError(t, err)
+	assert.Equal(t, "key", parsed.Header["kid"])
+	assert.Equal(t, info.IssuedAt.Unix(), claims.IssuedAt.Unix())
+	assert.Equal(t, info.ExpiresAt.Unix(), claims.ExpiresAt.Unix())
+
+	// 未过期时复用同一个client secret
+	again, err := auth.GenerateClientSecret()
+	assert.NoError(t, err)
+	assert.Equal(t, secret, again)
+}
+
+// go test -v -run TestClientSecretExpiryWarning
+func TestClientSecretExpiryWarning(t *testing.T) {
+	t.Parallel()
+
+	auth, err := NewTokenConfig("client", "team", "key", newTestPrivateKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, auth.SetClientSecretLifetime(10*24*time.Hour))
+
+	warned := make(chan ClientSecretInfo, 2)
+	auth.OnClientSecretExpiry(30*24*time.Hour, func(info ClientSecretInfo) {
+		warned <- info
+	})
+	for i := 0; i < 2; i++ {
+		_, err = auth.GenerateClientSecret()
+		assert.NoError(t, err)
+	}
+
+	select {
+	case info := <-warned:
+		assert.Equal(t, "key", info.KeyID)
+	case <-time.After(time.Second):
+		t.Fatal("expiry warning not called")
+	}
+	select {
+	case <-warned:
+		t.Fatal("expiry warning called more than once")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// go test -v -race -run TestRotateKey
+func TestRotateKey(t *testing.T) {
+	t.Parallel()
+
+	var (
+		exchanges int32
+		kids      = make(chan interface{}, 2)
+	)
+	auth := newTestTokenConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&exchanges, 1)
+		assert.NoError(t, r.ParseForm())
+		parsed, _, err := new(jwt.Parser).ParseUnverified(r.PostForm.Get("client_secret"), &jwt.StandardClaims{})
+		assert.NoError(t, err)
+		kids <- parsed.Header["kid"]
+		_, _ = w.Write([]byte(`{"access_token":"abc","token_type":"Bearer","expires_in":3600}`))
+	})
+	oldPub, err := auth.PublicKeyPEM()
+	assert.NoError(t, err)
+
+	_, err = auth.Token(context.Background())
+	assert.NoError(t, err)
+
+	key, err := ParsePrivateKey([]byte(newTestPrivateKey(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, auth.RotateKey("key2", key))
+	_, err = auth.Token(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&exchanges))
+	assert.Equal(t, "key", <-kids)
+	assert.Equal(t, "key2", <-kids)
+	newPub, err := auth.PublicKeyPEM()
+	assert.NoError(t, err)
+	assert.NotEqual(t, oldPub, newPub)
+	info, _ := auth.ClientSecretInfo()
+	assert.Equal(t, "key2", info.KeyID)
+
+	assert.Error(t, auth.RotateKey("", key))
+	assert.Error(t, auth.RotateKey("key3", nil))
+}
+
+// go test -v -race -run TestRotateKeyDuringExchange
+func TestRotateKeyDuringExchange(t *testing.T) {
+	t.Parallel()
+
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+		once    sync.Once
+	)
+	auth := newTestTokenConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		parsed, _, err := new(jwt.Parser).ParseUnverified(r.PostForm.Get("client_secret"), &jwt.StandardClaims{})
+		assert.NoError(t, err)
+		kid, _ := parsed.Header["kid"].(string)
+		if kid == "key" {
+			once.Do(func() { close(started) })
+			<-release
+		}
+		_, _ = w.Write([]byte(`{"access_token":"` + kid + `-token","token_type":"Bearer","expires_in":3600}`))
+	})
+	key, err := ParsePrivateKey([]byte(newTestPrivateKey(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := make(chan string, 1)
+	go func() {
+		token, err := auth.Token(context.Background())
+		assert.NoError(t, err)
+		if token != nil {
+			result <- token.AccessToken
+		}
+		close(result)
+	}()
+	<-started
+	assert.NoError(t, auth.RotateKey("key2", key))
+	close(release)
+
+	// 旧私钥换取的token被丢弃, 使用新私钥重新换取
+	assert.Equal(t, "key2-token", <-result)
+	token, err := auth.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "key2-token", token.AccessToken)
+}
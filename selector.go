@@ -0,0 +1,498 @@
+package asa
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSelectorLimit 每页记录数的最大值
+const maxSelectorLimit = 1000
+
+// SelectorResource 选择器作用的资源类型, 用于校验字段名
+type SelectorResource string
+
+const (
+	// SelectorResourceCampaign 广告系列
+	SelectorResourceCampaign SelectorResource = "campaign"
+	// SelectorResourceAdGroup 广告组
+	SelectorResourceAdGroup SelectorResource = "adgroup"
+	// SelectorResourceKeyword 定向关键词
+	SelectorResourceKeyword SelectorResource = "keyword"
+	// SelectorResourceNegativeKeyword 否定关键词
+	SelectorResourceNegativeKeyword SelectorResource = "negativekeyword"
+	// SelectorResourceAd 广告
+	SelectorResourceAd SelectorResource = "ad"
+	// SelectorResourceCreative 创意
+	SelectorResourceCreative SelectorResource = "creative"
+)
+
+// selectorModels 资源类型对应的模型, 可返回的字段取自模型的json tag
+var selectorModels = map[SelectorResource]reflect.Type{
+	SelectorResourceCampaign:        reflect.TypeOf(Campaign{}),
+	SelectorResourceAdGroup:         reflect.TypeOf(AdGroup{}),
+	SelectorResourceKeyword:         reflect.TypeOf(Keyword{}),
+	SelectorResourceNegativeKeyword: reflect.TypeOf(NegativeKeyword{}),
+	SelectorResourceAd:              reflect.TypeOf(Ad{}),
+	SelectorResourceCreative:        reflect.TypeOf(Creative{}),
+}
+
+// selectorConditionFields 资源可用于过滤条件的字段, 金额、发票信息、定向等对象字段API不接受作为条件;
+// 未列出的资源可使用模型的全部字段
+var selectorConditionFields = map[SelectorResource][]string{
+	SelectorResourceCampaign: {
+		"id", "orgId", "name", "adamId", "adChannelType", "billingEvent", "paymentModel",
+		"countriesOrRegions", "supplySources", "status", "servingStatus", "servingStateReasons",
+		"displayStatus", "deleted", "startTime", "endTime", "creationTime", "modificationTime",
+	},
+	SelectorResourceAdGroup: {
+		"id", "orgId", "campaignID", "name", "pricingModel", "paymentModel", "automatedKeywordsOptIn",
+		"status", "servingStatus", "servingStateReasons", "displayStatus", "deleted",
+		"startTime", "endTime", "creationTime", "modificationTime",
+	},
+	SelectorResourceKeyword: {
+		"id", "adGroupId", "campaignID", "text", "matchType", "status", "deleted", "creationTime", "modificationTime",
+	},
+	SelectorResourceNegativeKeyword: {
+		"id", "adGroupId", "campaignId", "text", "matchType", "status", "deleted", "creationTime", "modificationTime",
+	},
+}
+
+// selectorSortFields 资源可用于排序的字段, 列表与对象字段不能排序; 未列出的资源可使用模型的全部字段
+var selectorSortFields = map[SelectorResource][]string{
+	SelectorResourceCampaign: {
+		"id", "name", "adamId", "budgetAmount", "dailyBudgetAmount", "status", "servingStatus", "displayStatus",
+		"startTime", "endTime", "creationTime", "modificationTime",
+	},
+	SelectorResourceAdGroup: {
+		"id", "campaignID", "name", "defaultBidAmount", "cpaGoal", "status", "servingStatus", "displayStatus",
+		"startTime", "endTime", "creationTime", "modificationTime",
+	},
+	SelectorResourceKeyword: {
+		"id", "adGroupId", "text", "matchType", "bidAmount", "status", "creationTime", "modificationTime",
+	},
+	SelectorResourceNegativeKeyword: {
+		"id", "adGroupId", "text", "matchType", "status", "creationTime", "modificationTime",
+	},
+}
+
+// selectorFieldKind 字段在选择器中的用途
+type selectorFieldKind int
+
+const (
+	selectorFieldReturn selectorFieldKind = iota
+	selectorFieldCondition
+	selectorFieldSort
+)
+
+func (k selectorFieldKind) String() string {
+	switch k {
+	case selectorFieldCondition:
+		return "filterable"
+	case selectorFieldSort:
+		return "sortable"
+	}
+	return "returnable"
+}
+
+var (
+	selectorFieldsOnce sync.Once
+	selectorFieldSets  map[SelectorResource][3]map[string]bool
+)
+
+// SelectorFields 返回资源可用于选择器返回字段(selector.Fields)的字段名, 未知资源返回nil
+func SelectorFields(resource SelectorResource) []string {
+	return jsonFieldNames(selectorModels[resource])
+}
+
+// SelectorConditionFields 返回资源可用于过滤条件的字段名, 未知资源返回nil
+func SelectorConditionFields(resource SelectorResource) []string {
+	if fields, ok := selectorConditionFields[resource]; ok {
+		return append([]string(nil), fields...)
+	}
+	return SelectorFields(resource)
+}
+
+// SelectorSortFields 返回资源可用于排序的字段名, 未知资源返回nil
+func SelectorSortFields(resource SelectorResource) []string {
+	if fields, ok := selectorSortFields[resource]; ok {
+		return append([]string(nil), fields...)
+	}
+	return SelectorFields(resource)
+}
+
+// selectorFieldSet 资源在kind用途下可用字段的集合, 未知资源返回nil
+func selectorFieldSet(resource SelectorResource, kind selectorFieldKind) map[string]bool {
+	selectorFieldsOnce.Do(func() {
+		selectorFieldSets = make(map[SelectorResource][3]map[string]bool, len(selectorModels))
+		for res := range selectorModels {
+			selectorFieldSets[res] = [3]map[string]bool{
+				selectorFieldReturn:    stringSet(SelectorFields(res)),
+				selectorFieldCondition: stringSet(SelectorConditionFields(res)),
+				selectorFieldSort:      stringSet(SelectorSortFields(res)),
+			}
+		}
+	})
+	sets, ok := selectorFieldSets[resource]
+	if !ok {
+		return nil
+	}
+	return sets[kind]
+}
+
+// stringSet 将字符串列表转换为集合
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// jsonFieldName 结构体字段的json名称, 忽略的字段返回空字符串
+func jsonFieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return field.Name
+}
+
+// operatorArity 操作符需要的值的个数, max为-1时不限制上限
+var operatorArity = map[ConditionOperator]struct{ min, max int }{
+	ConditionOperatorBetween:     {2, 2},
+	ConditionOperatorContains:    {1, 1},
+	ConditionOperatorContainsAll: {1, -1},
+	ConditionOperatorContainsAny: {1, -1},
+	ConditionOperatorEndsWith:    {1, 1},
+	ConditionOperatorEquals:      {1, 1},
+	ConditionOperatorGreaterThan: {1, 1},
+	ConditionOperatorLessThan:    {1, 1},
+	ConditionOperatorStartsWith:  {1, 1},
+	ConditionOperatorIn:          {1, -1},
+	ConditionOperatorLike:        {1, 1},
+	ConditionOperatorNotEqual:    {1, 1},
+	ConditionOperatorIs:          {1, 1},
+}
+
+// validateCondition 校验操作符与值的个数
+func validateCondition(cond *Condition) error {
+	arity, ok := operatorArity[cond.Operator]
+	if !ok {
+		return fmt.Errorf("%s: unknown operator %q", cond.Field, cond.Operator)
+	}
+	n := len(cond.Values)
+	switch {
+	case arity.min == arity.max && n != arity.min:
+		return fmt.Errorf("%s: %s requires %d value(s), got %d", cond.Field, cond.Operator, arity.min, n)
+	case n < arity.min:
+		return fmt.Errorf("%s: %s requires at least %d value(s), got %d", cond.Field, cond.Operator, arity.min, n)
+	}
+	return nil
+}
+
+// SelectorError 选择器校验错误, 列出所有问题
+type SelectorError struct {
+	// Problems 每一项为一个问题的描述
+	Problems []string
+}
+
+func (e *SelectorError) Error() string {
+	return "asa: invalid selector: " + strings.Join(e.Problems, "; ")
+}
+
+// ValidateSelector 校验selector, resource为空时不校验字段名, 返回的 *SelectorError 包含所有问题
+func ValidateSelector(resource SelectorResource, selector *Selector) error {
+	if selector == nil {
+		return nil
+	}
+	selErr := &SelectorError{}
+	if resource != "" && selectorFieldSet(resource, selectorFieldReturn) == nil {
+		selErr.Problems = append(selErr.Problems, fmt.Sprintf("unknown resource %q", resource))
+		resource = ""
+	}
+	checkField := func(kind, field string, use selectorFieldKind) {
+		switch {
+		case field == "":
+			selErr.Problems = append(selErr.Problems, kind+": field is empty")
+		case resource == "" || selectorFieldSet(resource, use)[field]:
+		case !selectorFieldSet(resource, selectorFieldReturn)[field]:
+			selErr.Problems = append(selErr.Problems, fmt.Sprintf("%s: unknown %s field %q", kind, resource, field))
+		default:
+			selErr.Problems = append(selErr.Problems, fmt.Sprintf("%s: %s field %q is not %s", kind, resource, field, use))
+		}
+	}
+	for _, cond := range selector.Conditions {
+		if cond == nil {
+			continue
+		}
+		checkField("condition", cond.Field, selectorFieldCondition)
+		if err := validateCondition(cond); err != nil {
+			selErr.Problems = append(selErr.Problems, "condition "+err.Error())
+		}
+	}
+	for _, field := range selector.Fields {
+		checkField("fields", field, selectorFieldReturn)
+	}
+	for _, sorting := range selector.OrderBy {
+		if sorting == nil {
+			continue
+		}
+		checkField("orderBy", sorting.Field, selectorFieldSort)
+		if sorting.SortOrder != SortingOrderAscending && sorting.SortOrder != SortingOrderDescending {
+			selErr.Problems = append(selErr.Problems, fmt.Sprintf("orderBy %s: invalid sort order %q", sorting.Field, sorting.SortOrder))
+		}
+	}
+	if p := selector.Pagination; p != nil && p.Limit > maxSelectorLimit {
+		selErr.Problems = append(selErr.Problems, fmt.Sprintf("pagination: limit %d exceeds %d", p.Limit, maxSelectorLimit))
+	}
+	if len(selErr.Problems) == 0 {
+		return nil
+	}
+	return selErr
+}
+
+// SelectorBuilder 链式构造 Selector, 在 Build 时统一校验
+//
+//	selector, err := asa.NewCampaignSelector().
+//		Where("status").Equals(asa.CampaignStatusEnabled).
+//		Where("name").StartsWith("brand").
+//		OrderBy("name", asa.SortingOrderAscending).
+//		Limit(100).
+//		Build()
+type SelectorBuilder struct {
+	resource SelectorResource
+	selector Selector
+}
+
+// NewSelector 创建不校验字段名的选择器构造器
+func NewSelector() *SelectorBuilder {
+	return &SelectorBuilder{}
+}
+
+// NewSelectorFor 创建校验resource字段名的选择器构造器
+func NewSelectorFor(resource SelectorResource) *SelectorBuilder {
+	return &SelectorBuilder{resource: resource}
+}
+
+// NewCampaignSelector 创建广告系列的选择器构造器
+func NewCampaignSelector() *SelectorBuilder {
+	return NewSelectorFor(SelectorResourceCampaign)
+}
+
+// NewAdGroupSelector 创建广告组的选择器构造器
+func NewAdGroupSelector() *SelectorBuilder {
+	return NewSelectorFor(SelectorResourceAdGroup)
+}
+
+// NewKeywordSelector 创建定向关键词的选择器构造器
+func NewKeywordSelector() *SelectorBuilder {
+	return NewSelectorFor(SelectorResourceKeyword)
+}
+
+// NewNegativeKeywordSelector 创建否定关键词的选择器构造器
+func NewNegativeKeywordSelector() *SelectorBuilder {
+	return NewSelectorFor(SelectorResourceNegativeKeyword)
+}
+
+// NewAdSelector 创建广告的选择器构造器
+func NewAdSelector() *SelectorBuilder {
+	return NewSelectorFor(SelectorResourceAd)
+}
+
+// NewCreativeSelector 创建创意的选择器构造器
+func NewCreativeSelector() *SelectorBuilder {
+	return NewSelectorFor(SelectorResourceCreative)
+}
+
+// Where 开始一个字段条件
+func (b *SelectorBuilder) Where(field string) *ConditionBuilder {
+	return &ConditionBuilder{builder: b, field: field}
+}
+
+// Condition 添加一个条件
+func (b *SelectorBuilder) Condition(field string, operator ConditionOperator, values ...interface{}) *SelectorBuilder {
+	b.selector.Conditions = append(b.selector.Conditions, &Condition{
+		Field:    field,
+		Operator: operator,
+		Values:   conditionValues(values),
+	})
+	return b
+}
+
+// Fields 设置返回的字段
+func (b *SelectorBuilder) Fields(fields ...string) *SelectorBuilder {
+	b.selector.Fields = append(b.selector.Fields, fields...)
+	return b
+}
+
+// OrderBy 添加排序字段
+func (b *SelectorBuilder) OrderBy(field string, order SortOrder) *SelectorBuilder {
+	b.selector.OrderBy = append(b.selector.OrderBy, &Sorting{Field: field, SortOrder: order})
+	return b
+}
+
+// Limit 设置每页记录数, 最大1000
+func (b *SelectorBuilder) Limit(n uint32) *SelectorBuilder {
+	b.pagination().Limit = n
+	return b
+}
+
+// Offset 设置起始位置
+func (b *SelectorBuilder) Offset(n uint32) *SelectorBuilder {
+	b.pagination().Offset = n
+	return b
+}
+
+func (b *SelectorBuilder) pagination() *Pagination {
+	if b.selector.Pagination == nil {
+		b.selector.Pagination = &Pagination{}
+	}
+	return b.selector.Pagination
+}
+
+// Build 校验并返回 Selector, 校验失败时返回 *SelectorError
+//
+// 返回的 Selector 为副本, 之后修改构造器不会影响已返回的结果。
+func (b *SelectorBuilder) Build() (*Selector, error) {
+	if err := ValidateSelector(b.resource, &b.selector); err != nil {
+		return nil, err
+	}
+	return b.clone(), nil
+}
+
+// MustBuild 与 Build 相同, 校验失败时panic, 适用于固定的选择器
+func (b *SelectorBuilder) MustBuild() *Selector {
+	selector, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return selector
+}
+
+func (b *SelectorBuilder) clone() *Selector {
+	s := &Selector{}
+	for _, cond := range b.selector.Conditions {
+		c := *cond
+		c.Values = append([]string(nil), cond.Values...)
+		s.Conditions = append(s.Conditions, &c)
+	}
+	s.Fields = append(s.Fields, b.selector.Fields...)
+	for _, sorting := range b.selector.OrderBy {
+		o := *sorting
+		s.OrderBy = append(s.OrderBy, &o)
+	}
+	if b.selector.Pagination != nil {
+		p := *b.selector.Pagination
+		s.Pagination = &p
+	}
+	return s
+}
+
+// conditionValues 将条件的值转换为字符串, 时间按 ISO 8601 格式化
+func conditionValues(values []interface{}) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			out = append(out, v)
+		case time.Time:
+			out = append(out, v.Format(customISO8601Format))
+		case DateTime:
+			out = append(out, v.Time.Format(customISO8601Format))
+		case fmt.Stringer:
+			out = append(out, v.String())
+		default:
+			out = append(out, fmt.Sprint(v))
+		}
+	}
+	return out
+}
+
+// ConditionBuilder 字段条件构造器, 由 SelectorBuilder.Where 创建
+type ConditionBuilder struct {
+	builder *SelectorBuilder
+	field   string
+}
+
+func (c *ConditionBuilder) add(operator ConditionOperator, values ...interface{}) *SelectorBuilder {
+	return c.builder.Condition(c.field, operator, values...)
+}
+
+// Equals 字段等于value
+func (c *ConditionBuilder) Equals(value interface{}) *SelectorBuilder {
+	return c.add(ConditionOperatorEquals, value)
+}
+
+// NotEquals 字段不等于value
+func (c *ConditionBuilder) NotEquals(value interface{}) *SelectorBuilder {
+	return c.add(ConditionOperatorNotEqual, value)
+}
+
+// In 字段等于values中的任意一个
+func (c *ConditionBuilder) In(values ...interface{}) *SelectorBuilder {
+	return c.add(ConditionOperatorIn, values...)
+}
+
+// Between 字段在from与to之间
+func (c *ConditionBuilder) Between(from, to interface{}) *SelectorBuilder {
+	return c.add(ConditionOperatorBetween, from, to)
+}
+
+// Contains 字段包含value
+func (c *ConditionBuilder) Contains(value interface{}) *SelectorBuilder {
+	return c.add(ConditionOperatorContains, value)
+}
+
+// ContainsAll 列表字段包含values中的所有值
+func (c *ConditionBuilder) ContainsAll(values ...interface{}) *SelectorBuilder {
+	return c.add(ConditionOperatorContainsAll, values...)
+}
+
+// ContainsAny 列表字段包含values中的任意一个值
+func (c *ConditionBuilder) ContainsAny(values ...interface{}) *SelectorBuilder {
+	return c.add(ConditionOperatorContainsAny, values...)
+}
+
+// StartsWith 字段以prefix开头
+func (c *ConditionBuilder) StartsWith(prefix string) *SelectorBuilder {
+	return c.add(ConditionOperatorStartsWith, prefix)
+}
+
+// EndsWith 字段以suffix结尾
+func (c *ConditionBuilder) EndsWith(suffix string) *SelectorBuilder {
+	return c.add(ConditionOperatorEndsWith, suffix)
+}
+
+// Like 字段与value模糊匹配
+func (c *ConditionBuilder) Like(value string) *SelectorBuilder {
+	return c.add(ConditionOperatorLike, value)
+}
+
+// GreaterThan 字段大于value
+func (c *ConditionBuilder) GreaterThan(value interface{}) *SelectorBuilder {
+	return c.add(ConditionOperatorGreaterThan, value)
+}
+
+// LessThan 字段小于value
+func (c *ConditionBuilder) LessThan(value interface{}) *SelectorBuilder {
+	return c.add(ConditionOperatorLessThan, value)
+}
+
+// Is 字段为value
+func (c *ConditionBuilder) Is(value interface{}) *SelectorBuilder {
+	return c.add(ConditionOperatorIs, value)
+}
+
+// Op 使用任意操作符添加条件, 值的个数在 Build 时校验
+func (c *ConditionBuilder) Op(operator ConditionOperator, values ...interface{}) *SelectorBuilder {
+	return c.add(operator, values...)
+}
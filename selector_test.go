@@ -0,0 +1,127 @@
+package asa
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -run TestSelectorBuilder
+func TestSelectorBuilder(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	builder := NewCampaignSelector().
+		Where("status").Equals(CampaignStatusEnabled).
+		Where("id").In(int64(1), int64(2)).
+		Where("startTime").Between(start, start.Add(time.Hour)).
+		Where("countriesOrRegions").Contains("US").
+		Fields("id", "name").
+		OrderBy("name", SortingOrderAscending).
+		Limit(100).
+		Offset(200)
+	selector, err := builder.Build()
+	assert.NoError(t, err)
+
+	content, err := json.Marshal(selector)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"conditions":[
+			{"field":"status","operator":"EQUALS","values":["ENABLED"]},
+			{"field":"id","operator":"IN","values":["1","2"]},
+			{"field":"startTime","operator":"BETWEEN","values":["2024-01-02T03:04:05.000","2024-01-02T04:04:05.000"]},
+			{"field":"countriesOrRegions","operator":"CONTAINS","values":["US"]}
+		],
+		"fields":["id","name"],
+		"orderBy":[{"field":"name","sortOrder":"ASCENDING"}],
+		"pagination":{"limit":100,"offset":200}
+	}`, string(content))
+
+	// 返回的 Selector 为副本
+	builder.Limit(10).Where("name").StartsWith("brand")
+	assert.Len(t, selector.Conditions, 4)
+	assert.Equal(t, uint32(100), selector.Pagination.Limit)
+
+	// 不校验字段名
+	selector, err = NewSelector().Where("anything").Like("x").Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "anything", selector.Conditions[0].Field)
+}
+
+// go test -v -run TestSelectorValidation
+func TestSelectorValidation(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewKeywordSelector().
+		Where("text").Op(ConditionOperatorBetween, "a").
+		Where("matchType").In().
+		Where("bidAmount").Equals("1").
+		Where("text").Op("MATCHES", "a").
+		Fields("status", "unknown").
+		OrderBy("text", "UP").
+		Limit(5000).
+		Build()
+	var selErr *SelectorError
+	if assert.True(t, errors.As(err, &selErr)) {
+		assert.Equal(t, []string{
+			"condition text: BETWEEN requires 2 value(s), got 1",
+			"condition matchType: IN requires at least 1 value(s), got 0",
+			`condition: keyword field "bidAmount" is not filterable`,
+			`condition text: unknown operator "MATCHES"`,
+			`fields: unknown keyword field "unknown"`,
+			`orderBy text: invalid sort order "UP"`,
+			"pagination: limit 5000 exceeds 1000",
+		}, selErr.Problems)
+	}
+
+	assert.Panics(t, func() {
+		NewAdGroupSelector().Where("cpaGoal").Equals(1).Where("").Equals(1).MustBuild()
+	})
+	assert.NotPanics(t, func() {
+		NewNegativeKeywordSelector().Where("adGroupId").Equals(1).MustBuild()
+	})
+
+	assert.NoError(t, ValidateSelector(SelectorResourceCampaign, nil))
+	assert.Error(t, ValidateSelector("unknown", &Selector{}))
+	assert.Contains(t, SelectorFields(SelectorResourceAdGroup), "defaultBidAmount")
+	assert.Nil(t, SelectorFields("unknown"))
+
+	// 金额与对象字段可以返回, 但不能作为过滤条件; 列表字段不能排序
+	_, err = NewCampaignSelector().
+		Where("budgetAmount").GreaterThan(1).
+		Where("locInvoiceDetails").Equals("x").
+		Fields("budgetAmount", "locInvoiceDetails").
+		OrderBy("countriesOrRegions", SortingOrderAscending).
+		OrderBy("dailyBudgetAmount", SortingOrderDescending).
+		Build()
+	if assert.True(t, errors.As(err, &selErr)) {
+		assert.Equal(t, []string{
+			`condition: campaign field "budgetAmount" is not filterable`,
+			`condition: campaign field "locInvoiceDetails" is not filterable`,
+			`orderBy: campaign field "countriesOrRegions" is not sortable`,
+		}, selErr.Problems)
+	}
+	assert.NotContains(t, SelectorConditionFields(SelectorResourceCampaign), "budgetAmount")
+	assert.Contains(t, SelectorSortFields(SelectorResourceKeyword), "bidAmount")
+	assert.Equal(t, SelectorFields(SelectorResourceAd), SelectorConditionFields(SelectorResourceAd))
+	assert.Nil(t, SelectorConditionFields("unknown"))
+}
+
+// go test -v -run TestSelectorFieldLists
+func TestSelectorFieldLists(t *testing.T) {
+	t.Parallel()
+
+	// 可过滤、可排序的字段都必须是模型中的字段
+	for resource := range selectorModels {
+		fields := SelectorFields(resource)
+		for _, field := range SelectorConditionFields(resource) {
+			assert.Contains(t, fields, field, resource)
+		}
+		for _, field := range SelectorSortFields(resource) {
+			assert.Contains(t, fields, field, resource)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+//go:build go1.21
+
+package asa
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+// LogLevel 日志详细程度
+type LogLevel int
+
+const (
+	// LogSummary 只记录请求方法、地址、状态码、耗时等摘要信息
+	LogSummary LogLevel = iota
+	// LogBody 额外以Debug级别记录脱敏后的请求头与请求、响应内容
+	LogBody
+)
+
+// NewLoggingMiddleware 创建基于slog的请求日志中间件
+//
+// 成功的请求以Info级别记录, 失败或状态码>=400以Warn级别记录;
+// Authorization请求头、client secret、access token与私钥均会脱敏。
+func NewLoggingMiddleware(logger *slog.Logger, level LogLevel) Middleware {
+	return Middleware{
+		BeforeRequest: func(ctx context.Context, req *RequestInfo) error {
+			if level < LogBody || !logger.Enabled(ctx, slog.LevelDebug) {
+				return nil
+			}
+			logger.LogAttrs(ctx, slog.LevelDebug, "asa request",
+				slog.String("method", req.Method),
+				slog.String("url", redactURL(req.URL)),
+				slog.Int64("org_id", req.OrgID),
+				slog.Int("attempt", req.Attempt),
+				slog.Any("header", redactHeader(req.Header)),
+				slog.String("body", redactText(string(req.Body))),
+			)
+			return nil
+		},
+		AfterResponse: func(ctx context.Context, res *ResponseInfo) {
+			lvl := slog.LevelInfo
+			if res.Err != nil || res.StatusCode >= http.StatusBadRequest {
+				lvl = slog.LevelWarn
+			}
+			attrs := []slog.Attr{
+				slog.String("method", res.Request.Method),
+				slog.String("url", redactURL(res.Request.URL)),
+				slog.Int64("org_id", res.Request.OrgID),
+				slog.Int("attempt", res.Request.Attempt),
+				slog.Int("status", res.StatusCode),
+				slog.Duration("latency", res.Latency),
+			}
+			if res.Err != nil {
+				attrs = append(attrs, slog.String("error", redactText(res.Err.Error())))
+			}
+			logger.LogAttrs(ctx, lvl, "asa response", attrs...)
+
+			if level < LogBody || !logger.Enabled(ctx, slog.LevelDebug) {
+				return
+			}
+			logger.LogAttrs(ctx, slog.LevelDebug, "asa response body",
+				slog.String("method", res.Request.Method),
+				slog.String("url", redactURL(res.Request.URL)),
+				slog.Any("header", redactHeader(res.Header)),
+				slog.String("body", redactText(string(res.Body))),
+			)
+		},
+	}
+}
+
+// NewTokenLoggingHook 创建基于slog的access token换取日志回调, 不会记录client secret与access token
+func NewTokenLoggingHook(logger *slog.Logger) TokenHook {
+	return TokenHook{
+		AfterExchange: func(ctx context.Context, info *TokenExchangeInfo) {
+			attrs := []slog.Attr{
+				slog.String("client_id", info.ClientID),
+				slog.String("url", info.URL),
+				slog.Int("status", info.StatusCode),
+				slog.Duration("latency", info.Latency),
+			}
+			lvl := slog.LevelInfo
+			if info.Err != nil {
+				lvl = slog.LevelWarn
+				attrs = append(attrs, slog.String("error", redactText(info.Err.Error())))
+			} else {
+				attrs = append(attrs, slog.Duration("expires_in", info.ExpiresIn))
+			}
+			logger.LogAttrs(ctx, lvl, "asa token exchange", attrs...)
+		},
+	}
+}
+
+// SetLogger 使用slog记录access token换取
+func (t *TokenConfig) SetLogger(logger *slog.Logger) {
+	t.Use(NewTokenLoggingHook(logger))
+}
+
+// WithLogger 使用slog记录每次请求, 如需记录access token换取请调用 TokenConfig.SetLogger
+func WithLogger(logger *slog.Logger, level LogLevel) Option {
+	return WithMiddleware(NewLoggingMiddleware(logger, level))
+}
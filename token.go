@@ -0,0 +1,86 @@
+package asa
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Token access token及其过期时间
+type Token struct {
+	// AccessToken 请求时放在Authorization请求头中的Bearer token
+	AccessToken string
+	// Expiry 过期时间, 零值表示不会过期
+	Expiry time.Time
+}
+
+// Valid 判断token是否非空且未过期
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Before(t.Expiry)
+}
+
+// TokenSource access token来源, 客户端每次请求前调用 Token 获取access token
+//
+// 实现需要可以被多个goroutine并发调用, 并自行缓存未过期的token;
+// 例如 TokenConfig 使用client secret换取token, StaticTokenSource 返回固定token,
+// 也可以实现为从多个实例共享的token服务获取。
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// staticTokenSource 固定的access token
+type staticTokenSource struct {
+	token *Token
+}
+
+// StaticTokenSource 返回固定access token的 TokenSource, 适用于由外部负责刷新的场景
+func StaticTokenSource(accessToken string) TokenSource {
+	return &staticTokenSource{token: &Token{AccessToken: accessToken}}
+}
+
+func (s *staticTokenSource) Token(ctx context.Context) (*Token, error) {
+	return s.token, nil
+}
+
+// TokenSourceFunc 将函数适配为 TokenSource
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+// Token 调用f
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// Token 使用client secret换取access token, 未过期时返回缓存的token
+func (t *TokenConfig) Token(ctx context.Context) (*Token, error) {
+	accToken, err := t.jwtGenerator.currentAccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Token{AccessToken: accToken.AccessToken, Expiry: accToken.expiresAfter}, nil
+}
+
+// errEmptyToken TokenSource 返回了空token
+var errEmptyToken = errors.New("asa: token source returned an empty access token")
+
+// WithTokenSource 使用自定义的 TokenSource 获取access token
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) error {
+		if ts == nil {
+			return errors.New("asa: token source is nil")
+		}
+		if c.tokenSource != nil {
+			return errors.New("asa: auth source already set")
+		}
+		c.tokenSource = ts
+		return nil
+	}
+}
+
+var (
+	_ TokenSource = (*TokenConfig)(nil)
+	_ TokenSource = (*staticTokenSource)(nil)
+	_ TokenSource = TokenSourceFunc(nil)
+)
@@ -0,0 +1,83 @@
+package asa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -run TestTokenSource
+func TestTokenSource(t *testing.T) {
+	t.Parallel()
+
+	var auths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"data":{"id":1}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	var calls int32
+	broker := TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 3 {
+			return nil, errors.New("broker unavailable")
+		}
+		return &Token{AccessToken: fmt.Sprintf("broker-%d", n), Expiry: time.Now().Add(time.Hour)}, nil
+	})
+	c, err := New(WithTokenSource(broker), WithBaseURL(srv.URL))
+	assert.NoError(t, err)
+
+	_, err = c.Campaigns.GetCampaign(1)
+	assert.NoError(t, err)
+	_, err = c.ForOrg(42).Campaigns.GetCampaign(1)
+	assert.NoError(t, err)
+	_, err = c.Campaigns.GetCampaign(1)
+	assert.EqualError(t, err, "broker unavailable")
+	assert.Equal(t, []string{"Bearer broker-1", "Bearer broker-2"}, auths)
+
+	_, err = New(WithTokenSource(broker), WithAccessToken("token"))
+	assert.Error(t, err)
+	_, err = New(WithTokenSource(nil))
+	assert.Error(t, err)
+}
+
+// go test -v -run TestStaticTokenSource
+func TestStaticTokenSource(t *testing.T) {
+	t.Parallel()
+
+	token, err := StaticTokenSource("token").Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token", token.AccessToken)
+	assert.True(t, token.Valid())
+
+	assert.False(t, (&Token{AccessToken: "token", Expiry: time.Now().Add(-time.Second)}).Valid())
+	assert.False(t, (&Token{}).Valid())
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent")
+	})
+	c.tokenSource = StaticTokenSource("")
+	_, err = c.Campaigns.GetCampaign(1)
+	assert.ErrorIs(t, err, errEmptyToken)
+}
+
+// go test -v -run TestTokenConfigTokenSource
+func TestTokenConfigTokenSource(t *testing.T) {
+	t.Parallel()
+
+	auth := newTestTokenConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token":"abc","token_type":"Bearer","expires_in":3600}`))
+	})
+	token, err := auth.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", token.AccessToken)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.Expiry, time.Minute)
+}
@@ -0,0 +1,156 @@
+package asa
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// defaultRefreshBefore access token过期前提前刷新的时间
+const defaultRefreshBefore = 5 * time.Minute
+
+// TokenCache access token缓存, 用于在多个进程或多次运行之间复用token
+//
+// key 由clientID与keyID组成, 实现需要可以被多个goroutine并发调用。
+type TokenCache interface {
+	// Load 读取缓存的token, 不存在时返回nil, nil
+	Load(ctx context.Context, key string) (*Token, error)
+	// Store 保存token
+	Store(ctx context.Context, key string, token *Token) error
+}
+
+// MemoryTokenCache 进程内的token缓存, 可在多个 TokenConfig 之间共享
+type MemoryTokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]*Token
+}
+
+// NewMemoryTokenCache 创建进程内的token缓存
+func NewMemoryTokenCache() *MemoryTokenCache {
+	return &MemoryTokenCache{tokens: make(map[string]*Token)}
+}
+
+// Load 读取缓存的token
+func (m *MemoryTokenCache) Load(ctx context.Context, key string) (*Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tokens[key], nil
+}
+
+// Store 保存token
+func (m *MemoryTokenCache) Store(ctx context.Context, key string, token *Token) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[key] = token
+	return nil
+}
+
+// FileTokenCache 基于文件的token缓存, 每个key一个文件, 文件权限为0600
+type FileTokenCache struct {
+	dir string
+}
+
+// NewFileTokenCache 创建基于文件的token缓存, dir不存在时会以0700权限创建
+func NewFileTokenCache(dir string) *FileTokenCache {
+	return &FileTokenCache{dir: dir}
+}
+
+// fileToken 缓存文件内容
+type fileToken struct {
+	AccessToken string    `json:"access_token"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+// path key对应的缓存文件, 使用哈希避免key中的特殊字符
+func (f *FileTokenCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, "asa-token-"+hex.EncodeToString(sum[:16])+".json")
+}
+
+// Load 读取缓存文件
+func (f *FileTokenCache) Load(ctx context.Context, key string) (*Token, error) {
+	content, err := os.ReadFile(f.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ft fileToken
+	if err := json.Unmarshal(content, &ft); err != nil {
+		return nil, err
+	}
+	return &Token{AccessToken: ft.AccessToken, Expiry: ft.Expiry}, nil
+}
+
+// Store 写入缓存文件, 先写临时文件再重命名, 避免其他进程读到不完整的内容
+func (f *FileTokenCache) Store(ctx context.Context, key string, token *Token) error {
+	if err := os.MkdirAll(f.dir, 0o700); err != nil {
+		return err
+	}
+	content, err := json.Marshal(fileToken{AccessToken: token.AccessToken, Expiry: token.Expiry})
+	if err != nil {
+		return err
+	}
+	// CreateTemp 创建的文件权限为0600
+	tmp, err := os.CreateTemp(f.dir, ".asa-token-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(key))
+}
+
+// SetTokenCache 设置access token缓存, 换取token前先从缓存读取, 换取后写入缓存
+//
+// 缓存读写失败时不影响换取token。
+func (t *TokenConfig) SetTokenCache(cache TokenCache) {
+	g := t.jwtGenerator
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.cache = cache
+}
+
+// SetRefreshBefore 设置access token过期前提前刷新的时间, 默认5分钟
+func (t *TokenConfig) SetRefreshBefore(d time.Duration) {
+	g := t.jwtGenerator
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.refreshBefore = d
+}
+
+// cacheKey 缓存key, 同一个client的不同私钥分开缓存, 调用方需持有锁
+func (g *standardJWTGenerator) cacheKey() string {
+	return g.clientID + "/" + g.keyID
+}
+
+// loadCachedAccessToken 从缓存读取仍在有效期内的access token
+func loadCachedAccessToken(ctx context.Context, cache TokenCache, key string, refreshBefore time.Duration) *accessToken {
+	token, err := cache.Load(ctx, key)
+	if err != nil || token == nil || token.AccessToken == "" {
+		return nil
+	}
+	if !time.Now().Add(refreshBefore).Before(token.Expiry) {
+		return nil
+	}
+	return &accessToken{
+		AccessToken:  token.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(time.Until(token.Expiry).Seconds()),
+		expiresAfter: token.Expiry,
+	}
+}
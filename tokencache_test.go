@@ -0,0 +1,90 @@
+package asa
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test -v -run TestFileTokenCache
+func TestFileTokenCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cache := NewFileTokenCache(filepath.Join(t.TempDir(), "tokens"))
+
+	token, err := cache.Load(ctx, "client/key")
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	assert.NoError(t, cache.Store(ctx, "client/key", &Token{AccessToken: "abc", Expiry: expiry}))
+	token, err = cache.Load(ctx, "client/key")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", token.AccessToken)
+	assert.True(t, expiry.Equal(token.Expiry))
+
+	info, err := os.Stat(cache.path("client/key"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	token, err = cache.Load(ctx, "client/other")
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+}
+
+// go test -v -run TestTokenConfigCache
+func TestTokenConfigCache(t *testing.T) {
+	t.Parallel()
+
+	var exchanges int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&exchanges, 1)
+		_, _ = w.Write([]byte(`{"access_token":"abc","token_type":"Bearer","expires_in":3600}`))
+	}
+	cache := NewFileTokenCache(t.TempDir())
+
+	// 模拟两次进程运行, 第二次直接使用缓存的token
+	for i := 0; i < 2; i++ {
+		auth := newTestTokenConfig(t, handler)
+		auth.SetTokenCache(cache)
+		token, err := auth.Token(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", token.AccessToken)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&exchanges))
+}
+
+// go test -v -run TestTokenConfigRefreshBefore
+func TestTokenConfigRefreshBefore(t *testing.T) {
+	t.Parallel()
+
+	var exchanges int32
+	auth := newTestTokenConfig(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&exchanges, 1)
+		_, _ = w.Write([]byte(`{"access_token":"abc","token_type":"Bearer","expires_in":60}`))
+	})
+	cache := NewMemoryTokenCache()
+	auth.SetTokenCache(cache)
+
+	// 有效期短于提前刷新时间, 每次都重新换取且不使用缓存
+	for i := 0; i < 2; i++ {
+		_, err := auth.Token(context.Background())
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&exchanges))
+
+	// 关闭提前刷新后复用上次换取的token
+	auth.SetRefreshBefore(0)
+	for i := 0; i < 2; i++ {
+		_, err := auth.Token(context.Background())
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&exchanges))
+}
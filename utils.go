@@ -1,11 +1,9 @@
 package asa
 
 import (
-	"crypto/ecdsa"
-	"crypto/x509"
 	"encoding/json"
-	"encoding/pem"
 	"fmt"
+	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
@@ -21,6 +19,15 @@ func addParamsToQuery(query url.Values, params interface{}) error {
 	return addParamsToQueryRecursive(query, v.Elem())
 }
 
+// isNilPointer 判断v是否为nil或nil指针
+func isNilPointer(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
 func addParamsToQueryRecursive(query url.Values, v reflect.Value) error {
 	t := v.Type()
 
@@ -45,19 +52,22 @@ func addParamsToQueryRecursive(query url.Values, v reflect.Value) error {
 
 		jsonName := strings.Split(jsonTag, ",")[0]
 
-		// 处理默认值
+		// 处理默认值, 只写入查询参数, 不修改调用方的结构体
 		if strings.HasPrefix(paramTag, "default=") && field.IsZero() {
 			defaultValue := strings.TrimPrefix(paramTag, "default=")
 			switch field.Kind() {
-			case reflect.Int, reflect.Int64:
-				if val, err := strconv.ParseInt(defaultValue, 10, 64); err == nil {
-					field.SetInt(val)
+			case reflect.Int, reflect.Int32, reflect.Int64:
+				if val, err := strconv.ParseInt(defaultValue, 10, 64); err == nil && val != 0 {
+					query.Set(jsonName, strconv.FormatInt(val, 10))
 				}
 			case reflect.String:
-				field.SetString(defaultValue)
+				if defaultValue != "" {
+					query.Set(jsonName, defaultValue)
+				}
 			default:
 				panic("unhandled default case")
 			}
+			continue
 		}
 
 		// 根据字段类型和标签处理参数
@@ -97,16 +107,13 @@ func addParamsToQueryRecursive(query url.Values, v reflect.Value) error {
 	return nil
 }
 
-func parsePrivateKey(blob []byte) (*ecdsa.PrivateKey, error) {
-	block, _ := pem.Decode(blob)
-	if block == nil {
-		return nil, ErrMissingPEM
-	}
-
-	parsedKey, err := x509.ParseECPrivateKey(block.Bytes)
+// proxyTransport 创建使用指定代理的Transport
+func proxyTransport(proxyUrl string) (*http.Transport, error) {
+	urlProxy, err := url.Parse(proxyUrl)
 	if err != nil {
 		return nil, err
 	}
-
-	return parsedKey, nil
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(urlProxy)
+	return transport, nil
 }